@@ -1,7 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"short-go/internal/shared/timeutil"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +20,57 @@ type Config struct {
 	JWTRefreshExpiration string
 	EmailsAPIKey         string
 	SenderEmail          string
+
+	// Política de expiración de enlaces (0 en un máximo = sin límite)
+	AnonymousLinkDefaultTTL time.Duration
+	AnonymousLinkMaxTTL     time.Duration
+	UserLinkDefaultTTL      time.Duration
+	UserLinkMaxTTL          time.Duration
+
+	// Mantenimiento programado (0 en el intervalo = deshabilitado)
+	JanitorInterval   time.Duration
+	ExpiredLinkGrace  time.Duration
+	ExpiredLinkAction string // "delete" | "archive"
+
+	// Enlaces eliminados: tiempo en la papelera (restaurables) y tiempo que
+	// su código queda reservado antes de eliminarlos definitivamente
+	TrashRetention        time.Duration
+	DeletedCodeQuarantine time.Duration
+
+	// Enlaces con contraseña: firma y duración de la cookie de desbloqueo. El
+	// secreto también firma la confirmación de la advertencia de cuarentena
+	LinkUnlockSecret string
+	LinkUnlockTTL    time.Duration
+	// Intentos de contraseña permitidos por enlace e IP en cada ventana
+	LinkUnlockMaxAttempts   int
+	LinkUnlockAttemptWindow time.Duration
+
+	// Base de datos GeoIP local en CSV (vacío = sin resolución local)
+	GeoIPDatabasePath string
+
+	// Tiempo que navegadores y CDNs pueden cachear las redirecciones permanentes
+	PermanentRedirectMaxAge time.Duration
+
+	// Validación de destinos: esquemas permitidos, esquemas de apps solo para
+	// reglas por dispositivo y largo máximo de una URL
+	AllowedURLSchemes []string
+	DeepLinkSchemes   []string
+	MaxURLLength      int
+
+	// Listas locales de dominios/URLs bloqueados y qué hacer con las coincidencias
+	SafetyBlocklistFiles  []string
+	SafetyBlocklistAction string // "reject" | "quarantine"
+
+	// Proxies (CIDR o IP) cuyos X-Forwarded-For/X-Real-IP se aceptan; vacío =
+	// se usa siempre la IP de la conexión
+	TrustedProxies []string
+
+	// Cuentas con rol admin (moderación de enlaces)
+	AdminEmails []string
+
+	// Reportes de enlaces permitidos por IP y por cuenta en cada ventana
+	ReportMaxPerWindow int
+	ReportWindow       time.Duration
 }
 
 func LoadConfig() (*Config, error) {
@@ -25,6 +81,96 @@ func LoadConfig() (*Config, error) {
 		domain = getEnv("DEV_URL", "")
 	}
 
+	anonymousLinkDefaultTTL, err := getEnvDuration("ANON_LINK_DEFAULT_TTL", "60d")
+	if err != nil {
+		return nil, err
+	}
+	anonymousLinkMaxTTL, err := getEnvDuration("ANON_LINK_MAX_TTL", "60d")
+	if err != nil {
+		return nil, err
+	}
+	userLinkDefaultTTL, err := getEnvDuration("USER_LINK_DEFAULT_TTL", "60d")
+	if err != nil {
+		return nil, err
+	}
+	userLinkMaxTTL, err := getEnvDuration("USER_LINK_MAX_TTL", "0")
+	if err != nil {
+		return nil, err
+	}
+
+	janitorInterval, err := getEnvDuration("JANITOR_INTERVAL", "1h")
+	if err != nil {
+		return nil, err
+	}
+	expiredLinkGrace, err := getEnvDuration("EXPIRED_LINK_GRACE", "7d")
+	if err != nil {
+		return nil, err
+	}
+	expiredLinkAction := getEnv("EXPIRED_LINK_ACTION", "delete")
+	if expiredLinkAction != "delete" && expiredLinkAction != "archive" {
+		return nil, fmt.Errorf("EXPIRED_LINK_ACTION inválido (%q): usa 'delete' o 'archive'", expiredLinkAction)
+	}
+
+	trashRetention, err := getEnvDuration("TRASH_RETENTION", "30d")
+	if err != nil {
+		return nil, err
+	}
+	deletedCodeQuarantine, err := getEnvDuration("DELETED_CODE_QUARANTINE", "90d")
+	if err != nil {
+		return nil, err
+	}
+	if deletedCodeQuarantine < trashRetention {
+		return nil, fmt.Errorf("DELETED_CODE_QUARANTINE debe ser mayor o igual a TRASH_RETENTION")
+	}
+
+	linkUnlockTTL, err := getEnvDuration("LINK_UNLOCK_TTL", "24h")
+	if err != nil {
+		return nil, err
+	}
+	linkUnlockMaxAttempts, err := getEnvInt("LINK_UNLOCK_MAX_ATTEMPTS", 10)
+	if err != nil {
+		return nil, err
+	}
+	linkUnlockAttemptWindow, err := getEnvDuration("LINK_UNLOCK_ATTEMPT_WINDOW", "15m")
+	if err != nil {
+		return nil, err
+	}
+	if linkUnlockAttemptWindow <= 0 {
+		return nil, fmt.Errorf("LINK_UNLOCK_ATTEMPT_WINDOW debe ser mayor a 0")
+	}
+
+	permanentRedirectMaxAge, err := getEnvDuration("PERMANENT_REDIRECT_MAX_AGE", "24h")
+	if err != nil {
+		return nil, err
+	}
+
+	maxURLLength, err := getEnvInt("MAX_URL_LENGTH", 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	reportMaxPerWindow, err := getEnvInt("REPORT_MAX_PER_WINDOW", 10)
+	if err != nil {
+		return nil, err
+	}
+	reportWindow, err := getEnvDuration("REPORT_WINDOW", "1h")
+	if err != nil {
+		return nil, err
+	}
+	if reportWindow <= 0 {
+		return nil, fmt.Errorf("REPORT_WINDOW debe ser mayor a 0")
+	}
+
+	safetyBlocklistAction := getEnv("SAFETY_BLOCKLIST_ACTION", "reject")
+	if safetyBlocklistAction != "reject" && safetyBlocklistAction != "quarantine" {
+		return nil, fmt.Errorf("SAFETY_BLOCKLIST_ACTION inválido (%q): usa 'reject' o 'quarantine'", safetyBlocklistAction)
+	}
+
+	// Los enlaces anónimos siempre deben expirar
+	if anonymousLinkDefaultTTL <= 0 || anonymousLinkMaxTTL <= 0 {
+		return nil, fmt.Errorf("ANON_LINK_DEFAULT_TTL y ANON_LINK_MAX_TTL deben ser mayores a 0")
+	}
+
 	return &Config{
 		Port:                 getEnv("PORT", "8080"),
 		Domain:               domain,
@@ -34,6 +180,43 @@ func LoadConfig() (*Config, error) {
 		JWTRefreshExpiration: getEnv("JWT_REFRESH_EXPIRATION", "7d"),
 		EmailsAPIKey:         getEnv("EMAILS_API_KEY", ""),
 		SenderEmail:          getEnv("SENDER_EMAIL", ""),
+
+		AnonymousLinkDefaultTTL: anonymousLinkDefaultTTL,
+		AnonymousLinkMaxTTL:     anonymousLinkMaxTTL,
+		UserLinkDefaultTTL:      userLinkDefaultTTL,
+		UserLinkMaxTTL:          userLinkMaxTTL,
+
+		JanitorInterval:   janitorInterval,
+		ExpiredLinkGrace:  expiredLinkGrace,
+		ExpiredLinkAction: expiredLinkAction,
+
+		TrashRetention:        trashRetention,
+		DeletedCodeQuarantine: deletedCodeQuarantine,
+
+		// Por defecto reutiliza el secreto JWT
+		LinkUnlockSecret: getEnv("LINK_UNLOCK_SECRET", getEnv("JWT_SECRET", "super-secret-key")),
+		LinkUnlockTTL:    linkUnlockTTL,
+
+		LinkUnlockMaxAttempts:   linkUnlockMaxAttempts,
+		LinkUnlockAttemptWindow: linkUnlockAttemptWindow,
+
+		GeoIPDatabasePath: getEnv("GEOIP_DB_PATH", ""),
+
+		PermanentRedirectMaxAge: permanentRedirectMaxAge,
+
+		AllowedURLSchemes: getEnvList("ALLOWED_URL_SCHEMES", "http,https"),
+		DeepLinkSchemes:   getEnvList("DEEP_LINK_SCHEMES", "itms-apps,itms-appss,market,intent"),
+		MaxURLLength:      maxURLLength,
+
+		SafetyBlocklistFiles:  getEnvList("SAFETY_BLOCKLIST_FILES", ""),
+		SafetyBlocklistAction: safetyBlocklistAction,
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES", ""),
+
+		AdminEmails: getEnvList("ADMIN_EMAILS", ""),
+
+		ReportMaxPerWindow: reportMaxPerWindow,
+		ReportWindow:       reportWindow,
 	}, nil
 }
 
@@ -44,3 +227,35 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+func getEnvInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("%s inválido (%q): debe ser un entero positivo", key, value)
+	}
+	return parsed, nil
+}
+
+// getEnvList lee una lista separada por comas, ignorando los elementos vacíos
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvDuration(key, defaultValue string) (time.Duration, error) {
+	value := getEnv(key, defaultValue)
+	duration, err := timeutil.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s inválido (%q): %w", key, value, err)
+	}
+	return duration, nil
+}
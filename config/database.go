@@ -4,6 +4,8 @@ import (
 	authGormModels "short-go/internal/auth/infrastructure/persistence/gorm"
 	shortLinksGormModels "short-go/internal/short-links/infrastructure/persistence/gorm"
 	analyticsGormModels "short-go/internal/analytics/infrastructure/persistence/gorm"
+	domainsGormModels "short-go/internal/domains/infrastructure/persistence/gorm"
+	bioPagesGormModels "short-go/internal/bio-pages/infrastructure/persistence/gorm"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -12,6 +14,8 @@ import (
 func InitDatabase(dns string) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(dns), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
+		// Traduce las violaciones de unicidad a gorm.ErrDuplicatedKey
+		TranslateError: true,
 	})
 
 	if err != nil {
@@ -24,12 +28,28 @@ func InitDatabase(dns string) (*gorm.DB, error) {
 		&authGormModels.SessionModel{},
 
 		&shortLinksGormModels.ShortLinkModel{},
+		&shortLinksGormModels.ArchivedShortLinkModel{},
+		&shortLinksGormModels.ReportModel{},
+		&shortLinksGormModels.RevisionModel{},
 
 		&analyticsGormModels.ClickModel{},
+
+		&domainsGormModels.DomainModel{},
+
+		&bioPagesGormModels.BioPageModel{},
+		&bioPagesGormModels.PageViewModel{},
 	); err != nil {
 		return nil, err
 	}
 
+	if err := shortLinksGormModels.MigrateSearchIndex(db); err != nil {
+		return nil, err
+	}
+
+	if err := shortLinksGormModels.RevokeOwnedManagementTokens(db); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
 
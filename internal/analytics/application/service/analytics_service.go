@@ -1,12 +1,14 @@
 package service
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"log"
 	"net/http"
 	analyticsModel "short-go/internal/analytics/domain/model"
 	analyticsRepo "short-go/internal/analytics/domain/repository"
+	"short-go/internal/shared/geoip"
 	shortLinkRepo "short-go/internal/short-links/domain/repository"
 	"strings"
 	"time"
@@ -17,16 +19,25 @@ var (
 	ErrLinkNotFound = errors.New("enlace no encontrado")
 )
 
+// Tiempo máximo de espera por espacio en el buffer para clicks garantizados
+const guaranteedEnqueueTimeout = 2 * time.Second
+
 type AnalyticsService struct {
 	clickRepo   analyticsRepo.ClickRepository
 	shortLinkRepo shortLinkRepo.ShortLinkRepository
 	clickChannel chan *analyticsModel.Click
+	geoResolver  geoip.Resolver
 }
 
-func NewAnalyticsService(clickRepo analyticsRepo.ClickRepository, shortLinkRepo shortLinkRepo.ShortLinkRepository) *AnalyticsService {
+func NewAnalyticsService(
+	clickRepo analyticsRepo.ClickRepository,
+	shortLinkRepo shortLinkRepo.ShortLinkRepository,
+	geoResolver geoip.Resolver,
+) *AnalyticsService {
 	s := &AnalyticsService{
 		clickRepo:     clickRepo,
 		shortLinkRepo: shortLinkRepo,
+		geoResolver:   geoResolver,
 		// Buffer de 100 clicks para aguantar picos de tráfico
 		clickChannel: make(chan *analyticsModel.Click, 100),
 	}
@@ -38,18 +49,10 @@ func NewAnalyticsService(clickRepo analyticsRepo.ClickRepository, shortLinkRepo
 }
 
 //  --------------- FUNCIONALIDAD DE REGISTRAR  ---------------
-func (s *AnalyticsService) TrackClick(code, ip, userAgen, referrer string) {
-	// Aqui se llamará a una API de GeoIP para obtener el countryCode real
-	countryCode := "XX"
-
-	click := &analyticsModel.Click{
-		LinkCode:    code,
-		IPAddress: ip,
-		UserAgent:  userAgen,
-		Referrer:    referrer,
-		CountryCode: countryCode,
-		ClickedAt: time.Now(),
-	}
+// TrackClick encola un click para guardarlo en segundo plano. Si el país
+// ya se resolvió durante la redirección, se reutiliza
+func (s *AnalyticsService) TrackClick(click *analyticsModel.Click) {
+	prepareClick(click)
 
 	// Enviar al canal para procesamiento asíncrono
 	select {
@@ -60,19 +63,28 @@ func (s *AnalyticsService) TrackClick(code, ip, userAgen, referrer string) {
 	}
 }
 
+// TrackGuaranteedClick registra un click que no puede perderse, como los de
+// enlaces con límite de clicks: espera espacio en el buffer y, si sigue
+// lleno, guarda el click de forma síncrona
+func (s *AnalyticsService) TrackGuaranteedClick(click *analyticsModel.Click) {
+	prepareClick(click)
+
+	select {
+	case s.clickChannel <- click:
+		// Click enviado al canal
+	case <-time.After(guaranteedEnqueueTimeout):
+		log.Println("Warning: Analytics buffer full, saving click synchronously")
+		s.persistClick(click)
+	}
+}
+
 func (s *AnalyticsService) processClicks() {
 	for click := range s.clickChannel {
-		if click.CountryCode == "XX" {
-			click.CountryCode = s.resolveCountryCode(click.IPAddress)
-		}
-
-		if err := s.clickRepo.Save(click); err != nil {
-			log.Printf("Error saving click: %v", err)
-		}
+		s.persistClick(click)
 	}
 }
 
-func (s *AnalyticsService) GetStats(code string, managementToken string, userID *string) (*analyticsModel.LinkStats, error) {
+func (s *AnalyticsService) GetStats(code string, managementToken string, userID *string, filter analyticsModel.StatsFilter) (*analyticsModel.LinkStats, error) {
 	link, err := s.shortLinkRepo.FindByCode(code)
 	if err != nil {
 		return nil, ErrLinkNotFound
@@ -84,7 +96,8 @@ func (s *AnalyticsService) GetStats(code string, managementToken string, userID
 		isAuthorized = true
 	}
 
-	if !isAuthorized && link.ManagementToken == managementToken {
+	if !isAuthorized && managementToken != "" &&
+		subtle.ConstantTimeCompare([]byte(managementToken), []byte(link.ManagementToken)) == 1 {
 		isAuthorized = true
 	}
 
@@ -92,11 +105,36 @@ func (s *AnalyticsService) GetStats(code string, managementToken string, userID
 		return nil, ErrUnauthorized
 	}
 
-	return s.clickRepo.GetLinkStats(code)
+	return s.clickRepo.GetLinkStats(code, filter)
 }
 
 
 // ---------------- FUNCIONES AUXILIARES  ---------------
+// prepareClick completa los valores por defecto de un click
+func prepareClick(click *analyticsModel.Click) {
+	if click.CountryCode == "" {
+		click.CountryCode = geoip.UnknownCountry
+	}
+	if click.ClickedAt.IsZero() {
+		click.ClickedAt = time.Now()
+	}
+}
+
+// persistClick resuelve el país del click (base local y, si no la conoce,
+// la API remota) y lo guarda
+func (s *AnalyticsService) persistClick(click *analyticsModel.Click) {
+	if click.CountryCode == geoip.UnknownCountry {
+		click.CountryCode = s.geoResolver.CountryCode(click.IPAddress)
+	}
+	if click.CountryCode == geoip.UnknownCountry {
+		click.CountryCode = s.resolveCountryCode(click.IPAddress)
+	}
+
+	if err := s.clickRepo.Save(click); err != nil {
+		log.Printf("Error saving click: %v", err)
+	}
+}
+
 func (s *AnalyticsService) resolveCountryCode(ip string) string {
 	if strings.Contains(ip, "127.0.0.1") || strings.Contains(ip, "::1") {
 		return "EC"
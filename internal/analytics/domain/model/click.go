@@ -9,15 +9,34 @@ type Click struct {
 	UserAgent   string    `json:"userAgent,omitempty"`
 	Referrer    string    `json:"referrer,omitempty"`
 	CountryCode string    `json:"countryCode,omitempty"`
+	Variant     string    `json:"variant,omitempty"` // variante A/B servida
+
+	// Parámetros UTM con los que se envió la visita al destino
+	UTMSource   string `json:"utmSource,omitempty"`
+	UTMMedium   string `json:"utmMedium,omitempty"`
+	UTMCampaign string `json:"utmCampaign,omitempty"`
+	UTMTerm     string `json:"utmTerm,omitempty"`
+	UTMContent  string `json:"utmContent,omitempty"`
 	ClickedAt   time.Time `json:"clickedAt"`
 }
 
+// StatsFilter restringe las estadísticas a los clicks con los UTM indicados;
+// los campos vacíos no filtran
+type StatsFilter struct {
+	UTMSource   string
+	UTMMedium   string
+	UTMCampaign string
+	UTMTerm     string
+	UTMContent  string
+}
+
 // Modelos adicionales para las estadisticas de un enlace
 type LinkStats struct {
 	TotalClicks  int64          `json:"totalClicks"`
 	ClicksByDate []DailyStat    `json:"clicksByDate"`
 	TopCountries []CountryStat  `json:"topCountries"`
 	TopReferrers []ReferrerStat `json:"topReferrers"`
+	Variants     []VariantStat  `json:"variants,omitempty"` // solo enlaces con rotación A/B
 	LastClicks   []Click        `json:"lastClicks"` //ultimos 10 visitantes
 }
 
@@ -37,3 +56,9 @@ type ReferrerStat struct {
 	Referrer string `json:"referrer"`
 	Count   int64  `json:"count"`
 }
+
+// VariantStat agrupa clicks por variante A/B servida
+type VariantStat struct {
+	Variant string `json:"variant"`
+	Count   int64  `json:"count"`
+}
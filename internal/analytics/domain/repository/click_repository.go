@@ -6,11 +6,13 @@ type ClickRepository interface {
 	Save(click *model.Click) error
 	
 	// Métodos de lectura para analytics (consultas pesadas con GROUP BY)
-	CountTotal(linkCode string) (int64, error)
-	GetClicksByDate(linkCode string) ([]model.DailyStat, error)
-	GetTopCountries(linkCode string, limit int) ([]model.CountryStat, error)
-	GetTopReferrers(linkCode string, limit int) ([]model.ReferrerStat, error)
+	// El filtro limita cada consulta a los clicks con los UTM indicados
+	CountTotal(linkCode string, filter model.StatsFilter) (int64, error)
+	GetClicksByDate(linkCode string, filter model.StatsFilter) ([]model.DailyStat, error)
+	GetTopCountries(linkCode string, filter model.StatsFilter, limit int) ([]model.CountryStat, error)
+	GetTopReferrers(linkCode string, filter model.StatsFilter, limit int) ([]model.ReferrerStat, error)
+	GetClicksByVariant(linkCode string, filter model.StatsFilter) ([]model.VariantStat, error)
 
 	// O un método maestro que traiga todas las estadísticas juntas
-	GetLinkStats(linkCode string) (*model.LinkStats, error)
+	GetLinkStats(linkCode string, filter model.StatsFilter) (*model.LinkStats, error)
 }
\ No newline at end of file
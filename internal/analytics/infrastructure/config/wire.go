@@ -4,6 +4,7 @@ import (
 	"short-go/internal/analytics/application/service"
 	"short-go/internal/analytics/infrastructure/http/handler"
 	gormAnalyticsRepo "short-go/internal/analytics/infrastructure/persistence/gorm"
+	"short-go/internal/shared/geoip"
 	shortLinkRepo "short-go/internal/short-links/domain/repository"
 	"short-go/internal/shared/infrastructure/middleware"
 
@@ -15,12 +16,12 @@ type AnalyticsModule struct {
 	Handler *handler.AnalyticsHandler
 }
 
-func NewAnalyticsModule(db *gorm.DB, linkRepo shortLinkRepo.ShortLinkRepository) *AnalyticsModule {
+func NewAnalyticsModule(db *gorm.DB, linkRepo shortLinkRepo.ShortLinkRepository, geoResolver geoip.Resolver) *AnalyticsModule {
 	// Repositories (ninguno por ahora)
 	clickRepo := gormAnalyticsRepo.NewClickRepository(db)
 
 	// Services
-	analyticsService := service.NewAnalyticsService(clickRepo, linkRepo)
+	analyticsService := service.NewAnalyticsService(clickRepo, linkRepo, geoResolver)
 
 	// Handlers
 	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"short-go/internal/analytics/application/service"
+	"short-go/internal/analytics/domain/model"
 	sharedContext "short-go/internal/shared/context"
 	sharedhttp "short-go/internal/shared/http"
 	"github.com/go-chi/chi/v5"
@@ -20,7 +21,8 @@ func NewAnalyticsHandler(serivce *service.AnalyticsService) *AnalyticsHandler {
 func (h *AnalyticsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	code := chi.URLParam(r, "code")
 
-	token := r.URL.Query().Get("token")
+	// El token solo se acepta en el header para que no quede en logs ni historiales
+	token := r.Header.Get(sharedhttp.ManagementTokenHeader)
 
 	rawUserID := sharedContext.GetUserID(r.Context())
 	var userID *string
@@ -28,7 +30,17 @@ func (h *AnalyticsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 		userID = &rawUserID
 	}
 
-	stats, err := h.service.GetStats(code, token, userID)
+	// Filtros opcionales por UTM: ?utm_source=newsletter&utm_campaign=launch
+	query := r.URL.Query()
+	filter := model.StatsFilter{
+		UTMSource:   query.Get("utm_source"),
+		UTMMedium:   query.Get("utm_medium"),
+		UTMCampaign: query.Get("utm_campaign"),
+		UTMTerm:     query.Get("utm_term"),
+		UTMContent:  query.Get("utm_content"),
+	}
+
+	stats, err := h.service.GetStats(code, token, userID, filter)
 
 	if err != nil {
 		if err == service.ErrUnauthorized {
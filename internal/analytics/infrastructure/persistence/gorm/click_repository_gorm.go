@@ -23,6 +23,12 @@ func (r *ClickRepositoryGorm) Save(click *model.Click) error {
 		Referrer:    click.Referrer,
 		IPAddress: click.IPAddress,
 		UserAgent: click.UserAgent,
+		Variant:   click.Variant,
+		UTMSource:   click.UTMSource,
+		UTMMedium:   click.UTMMedium,
+		UTMCampaign: click.UTMCampaign,
+		UTMTerm:     click.UTMTerm,
+		UTMContent:  click.UTMContent,
 	}
 
 	if err := r.db.Create(clickModel).Error; err != nil {
@@ -32,22 +38,39 @@ func (r *ClickRepositoryGorm) Save(click *model.Click) error {
 	return nil
 }
 
+// linkClicks arma la consulta base de los clicks de un enlace con el filtro UTM
+func (r *ClickRepositoryGorm) linkClicks(linkCode string, filter model.StatsFilter) *gorm.DB {
+	query := r.db.Model(&ClickModel{}).Where("link_code = ?", linkCode)
+
+	for column, value := range map[string]string{
+		"utm_source":   filter.UTMSource,
+		"utm_medium":   filter.UTMMedium,
+		"utm_campaign": filter.UTMCampaign,
+		"utm_term":     filter.UTMTerm,
+		"utm_content":  filter.UTMContent,
+	} {
+		if value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+
+	return query
+}
+
 // Métodos de lectura para analytics (consultas pesadas con GROUP BY)
-func (r *ClickRepositoryGorm) CountTotal(linkCode string) (int64, error) {
+func (r *ClickRepositoryGorm) CountTotal(linkCode string, filter model.StatsFilter) (int64, error) {
 	var count int64
-	err := r.db.Model(&ClickModel{}).
-			Where("link_code = ?", linkCode).
+	err := r.linkClicks(linkCode, filter).
 			Count(&count).Error
 	
 	return count, err
 }
 
-func (r *ClickRepositoryGorm) GetClicksByDate(linkCode string) ([]model.DailyStat, error) {
+func (r *ClickRepositoryGorm) GetClicksByDate(linkCode string, filter model.StatsFilter) ([]model.DailyStat, error) {
 	var stats []model.DailyStat
 
-	err := r.db.Model(&ClickModel{}).
+	err := r.linkClicks(linkCode, filter).
 			Select("TO_CHAR(clicked_at, 'YYYY-MM-DD') as date, COUNT(*) as count").
-			Where("link_code = ?", linkCode).
 			Group("TO_CHAR(clicked_at, 'YYYY-MM-DD')").
 			Order("date ASC").
 			Limit(30).
@@ -56,12 +79,11 @@ func (r *ClickRepositoryGorm) GetClicksByDate(linkCode string) ([]model.DailySta
 	return stats, err
 }
 
-func (r *ClickRepositoryGorm) GetTopCountries(linkCode string, limit int) ([]model.CountryStat, error) {
+func (r *ClickRepositoryGorm) GetTopCountries(linkCode string, filter model.StatsFilter, limit int) ([]model.CountryStat, error) {
 	var stats []model.CountryStat
 
-	err := r.db.Model(&ClickModel{}).
+	err := r.linkClicks(linkCode, filter).
 			Select("country_code, COUNT(*) as count").
-			Where("link_code = ?", linkCode).
 			Group("country_code").
 			Order("count DESC").
 			Limit(limit).
@@ -70,12 +92,12 @@ func (r *ClickRepositoryGorm) GetTopCountries(linkCode string, limit int) ([]mod
 	return stats, err
 }
 
-func (r *ClickRepositoryGorm) GetTopReferrers(linkCode string, limit int) ([]model.ReferrerStat, error) {
+func (r *ClickRepositoryGorm) GetTopReferrers(linkCode string, filter model.StatsFilter, limit int) ([]model.ReferrerStat, error) {
 	var stats []model.ReferrerStat
 
-	err := r.db.Model(&ClickModel{}).
+	err := r.linkClicks(linkCode, filter).
 			Select("referrer, COUNT(*) as count").
-			Where("link_code = ? AND referrer IS NOT NULL AND referrer <>''", linkCode).
+			Where("referrer IS NOT NULL AND referrer <>''").
 			Group("referrer").
 			Order("count DESC").
 			Limit(limit).
@@ -84,32 +106,50 @@ func (r *ClickRepositoryGorm) GetTopReferrers(linkCode string, limit int) ([]mod
 	return stats, err
 }
 
+func (r *ClickRepositoryGorm) GetClicksByVariant(linkCode string, filter model.StatsFilter) ([]model.VariantStat, error) {
+	var stats []model.VariantStat
+
+	err := r.linkClicks(linkCode, filter).
+			Select("variant, COUNT(*) as count").
+			Where("variant <> ''").
+			Group("variant").
+			Order("variant ASC").
+			Scan(&stats).Error
+
+	return stats, err
+}
+
 // O un método maestro que traiga todas las estadísticas juntas
-func (r *ClickRepositoryGorm) GetLinkStats(linkCode string) (*model.LinkStats, error) {
+func (r *ClickRepositoryGorm) GetLinkStats(linkCode string, filter model.StatsFilter) (*model.LinkStats, error) {
 	stats := &model.LinkStats{}
 	var err error
 
-	stats.TotalClicks, err = r.CountTotal(linkCode)
+	stats.TotalClicks, err = r.CountTotal(linkCode, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.ClicksByDate, err = r.GetClicksByDate(linkCode, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	stats.ClicksByDate, err = r.GetClicksByDate(linkCode)
+	stats.TopCountries, err = r.GetTopCountries(linkCode, filter, 5)
 	if err != nil {
 		return nil, err
 	}
 
-	stats.TopCountries, err = r.GetTopCountries(linkCode, 5)
+	stats.TopReferrers, err = r.GetTopReferrers(linkCode, filter, 5)
 	if err != nil {
 		return nil, err
 	}
 
-	stats.TopReferrers, err = r.GetTopReferrers(linkCode, 5)
+	stats.Variants, err = r.GetClicksByVariant(linkCode, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	stats.LastClicks, err = r.GetLastClicks(linkCode, 10)
+	stats.LastClicks, err = r.GetLastClicks(linkCode, filter, 10)
 	if err != nil {
 		return nil, err
 	}
@@ -117,10 +157,9 @@ func (r *ClickRepositoryGorm) GetLinkStats(linkCode string) (*model.LinkStats, e
 	return stats, nil
 }
 
-func (r *ClickRepositoryGorm) GetLastClicks(lickCode string, limit int) ([]model.Click, error) {
+func (r *ClickRepositoryGorm) GetLastClicks(lickCode string, filter model.StatsFilter, limit int) ([]model.Click, error) {
 	var clicks []model.Click
-	err := r.db.Model(&ClickModel{}).
-		Where("link_code = ?", lickCode).
+	err := r.linkClicks(lickCode, filter).
 		Order("clicked_at DESC").
 		Limit(limit).
 		Scan(&clicks).Error
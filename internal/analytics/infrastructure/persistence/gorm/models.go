@@ -10,6 +10,13 @@ type ClickModel struct {
 	UserAgent   string `gorm:"type:text"`
 	Referrer    string `gorm:"type:text"`
 	CountryCode string `gorm:"size:2;index"`
+	Variant     string `gorm:"size:32"`
+
+	UTMSource   string `gorm:"column:utm_source;type:text"`
+	UTMMedium   string `gorm:"column:utm_medium;type:text"`
+	UTMCampaign string `gorm:"column:utm_campaign;type:text;index"`
+	UTMTerm     string `gorm:"column:utm_term;type:text"`
+	UTMContent  string `gorm:"column:utm_content;type:text"`
 
 	ClickedAt time.Time `gorm:"autoCreateTime;index"`
 }
@@ -32,6 +32,9 @@ type AuthService struct {
 	jwtSecret   string
 
 	emailService EmailService
+
+	// Emails que reciben el rol admin al iniciar el servidor
+	adminEmails map[string]bool
 }
 
 func NewAuthService(
@@ -39,13 +42,31 @@ func NewAuthService(
 	sessionRepo repository.SessionRepository, 
 	jwtSecret string,
 	emailService EmailService,
+	adminEmails []string,
 	) *AuthService {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		admins[email] = true
+	}
+
 	return &AuthService{
 		userRepo:    userRepo,
 		sessionRepo: sessionRepo,
 		jwtSecret:   jwtSecret,
 		emailService: emailService,
+		adminEmails: admins,
+	}
+}
+
+// PromoteAdmins asigna el rol admin a las cuentas existentes de ADMIN_EMAILS.
+// Es la única vía para obtener el rol: registrarse con uno de esos emails no
+// lo otorga
+func (s *AuthService) PromoteAdmins() (int64, error) {
+	emails := make([]string, 0, len(s.adminEmails))
+	for email := range s.adminEmails {
+		emails = append(emails, email)
 	}
+	return s.userRepo.PromoteToAdmin(emails)
 }
 
 // Register- Registrar un usuario
@@ -76,6 +97,7 @@ func (s *AuthService) Register(email, password, name string) (*model.User, error
 		Password:  string(hashedPassword),
 		Name:      name,
 		IsActive:  true,
+		Role:      model.RoleUser,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -139,6 +161,7 @@ func (s *AuthService) Login(email, password string) (*model.User, string, string
 		Email:     user.Email,
 		Name:      user.Name,
 		IsActive:  user.IsActive,
+		Role:      user.Role,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
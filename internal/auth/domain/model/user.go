@@ -2,15 +2,26 @@ package model
 
 import "time"
 
+// Roles de usuario
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin" // puede moderar enlaces y revisar reportes
+)
+
 type User struct {
 	ID        string    `json:"id"`
 	Email     string    `json:"email"`
 	Password  string    `json:"-"` // "-" to omit in JSON responses
 	Name      string    `json:"name"`
 	IsActive  bool      `json:"isActive"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 
 	ResetPasswordToken     *string    `json:"-"`
 	ResetPasswordExpiresAt *time.Time `json:"-"`
 }
+
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
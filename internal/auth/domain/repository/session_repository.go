@@ -8,7 +8,7 @@ type SessionRepository interface {
 	FindByID(id string) (*model.Session, error)
 	FindActiveByUserID(userID string) ([]*model.Session, error)
 	DeleteByUserID(userID string) error
-	DeleteExpired() error
+	DeleteExpired() (int64, error)
 	CountByUserID(userID string) (int64, error)
 	DeleteOldestByUserID(userID string) error
 	DeleteExpiredByUserID(userID string) error
@@ -7,4 +7,6 @@ type UserRepository interface {
 	FindByEmail(email string) (*model.User, error)
 	FindByID(id string) (*model.User, error)
 	Update(user *model.User) error
+	ClearExpiredResetTokens() (int64, error)
+	PromoteToAdmin(emails []string) (int64, error)
 }
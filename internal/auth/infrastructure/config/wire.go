@@ -1,6 +1,7 @@
 package config
 
 import (
+	"log"
 	"short-go/internal/auth/application/service"
 	"short-go/internal/auth/infrastructure/email"
 	"short-go/internal/auth/infrastructure/http/handler"
@@ -15,14 +16,21 @@ type AuthModule struct {
 	Handler *handler.AuthHandler
 }
 
-func NewAuthModule(db *gorm.DB, jwtSecret string, emailsApiKey string, senderEmail string) *AuthModule {
+func NewAuthModule(db *gorm.DB, jwtSecret string, emailsApiKey string, senderEmail string, adminEmails []string) *AuthModule {
 	// Repositories
 	userRepo := gormRepo.NewUserRepository(db)
 	sessionRepo := gormRepo.NewSessionRepository(db)
 
 	// Services
 	emailService := email.NewBrevoEmailService(emailsApiKey, senderEmail)
-	authService := service.NewAuthService(userRepo, sessionRepo, jwtSecret, emailService)
+	authService := service.NewAuthService(userRepo, sessionRepo, jwtSecret, emailService, adminEmails)
+
+	// Las cuentas de ADMIN_EMAILS creadas antes de configurarlas también son admin
+	if promoted, err := authService.PromoteAdmins(); err != nil {
+		log.Printf("Warning: no se pudo asignar el rol admin: %v", err)
+	} else if promoted > 0 {
+		log.Printf("Rol admin asignado a %d usuario(s)", promoted)
+	}
 
 	// Handlers
 	authHandler := handler.NewAuthHandler(authService)
@@ -8,6 +8,7 @@ type UserModel struct {
 	Password  string    `gorm:"not null"`
 	Name      string    `gorm:"not null"`
 	IsActive  bool      `gorm:"default:true"`
+	Role      string    `gorm:"size:16;not null;default:'user'"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 
@@ -49,8 +49,9 @@ func (r *SessionRepositoryGorm) DeleteByUserID(userID string) error {
 	return r.db.Where("user_id = ?", userID).Delete(&SessionModel{}).Error
 }
 
-func (r *SessionRepositoryGorm) DeleteExpired() error {
-	return r.db.Where("expires_at < ?", gorm.Expr("NOW()")).Delete(&SessionModel{}).Error
+func (r *SessionRepositoryGorm) DeleteExpired() (int64, error) {
+	result := r.db.Where("expires_at < ?", gorm.Expr("NOW()")).Delete(&SessionModel{})
+	return result.RowsAffected, result.Error
 }
 
 func (r *SessionRepositoryGorm) CountByUserID(userID string) (int64, error) {
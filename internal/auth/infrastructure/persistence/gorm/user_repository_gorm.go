@@ -23,6 +23,7 @@ func (r *UserRepositoryGorm) Create(user *model.User) error {
 		Password: user.Password,
 		Name:     user.Name,
 		IsActive: user.IsActive,
+		Role:     user.Role,
 	}
 
 	// db.Create
@@ -47,6 +48,7 @@ func (r *UserRepositoryGorm) FindByEmail(email string) (*model.User, error) {
 		Password: userModel.Password,
 		Name:     userModel.Name,
 		IsActive: userModel.IsActive,
+		Role:     userModel.Role,
 
 		CreatedAt: userModel.CreatedAt,
 		UpdatedAt: userModel.UpdatedAt,
@@ -71,6 +73,7 @@ func (r *UserRepositoryGorm) FindByID(id string) (*model.User, error) {
 		Password: userModel.Password,
 		Name:     userModel.Name,
 		IsActive: userModel.IsActive,
+		Role:     userModel.Role,
 
 		ResetPasswordToken: userModel.ResetPasswordToken,
 		ResetPasswordExpiresAt: userModel.ResetPasswordExpiresAt,
@@ -90,6 +93,7 @@ func (r *UserRepositoryGorm) Update(user *model.User) error {
 		Password: user.Password,
 		Name:     user.Name,
 		IsActive: user.IsActive,
+		Role:     user.Role,
 
 		ResetPasswordToken: user.ResetPasswordToken,
 		ResetPasswordExpiresAt: user.ResetPasswordExpiresAt,
@@ -105,3 +109,26 @@ func (r *UserRepositoryGorm) Update(user *model.User) error {
 
 	return nil
 }
+
+func (r *UserRepositoryGorm) ClearExpiredResetTokens() (int64, error) {
+	result := r.db.Model(&UserModel{}).
+		Where("reset_password_token IS NOT NULL AND reset_password_expires_at < ?", gorm.Expr("NOW()")).
+		Updates(map[string]interface{}{
+			"reset_password_token":      nil,
+			"reset_password_expires_at": nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// PromoteToAdmin asigna el rol admin a los usuarios cuyo email guardado es
+// exactamente uno de los indicados, incluidas las mayúsculas: otra cuenta
+// que solo difiera en mayúsculas no recibe el rol
+func (r *UserRepositoryGorm) PromoteToAdmin(emails []string) (int64, error) {
+	if len(emails) == 0 {
+		return 0, nil
+	}
+	result := r.db.Model(&UserModel{}).
+		Where("email IN ? AND role <> ?", emails, model.RoleAdmin).
+		Update("role", model.RoleAdmin)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net/url"
+	"regexp"
+	"short-go/internal/bio-pages/domain/model"
+	"short-go/internal/bio-pages/domain/repository"
+	"short-go/internal/shared/geoip"
+	shortLinkModel "short-go/internal/short-links/domain/model"
+	shortLinkRepo "short-go/internal/short-links/domain/repository"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBioPageNotFound  = errors.New("página no encontrada")
+	ErrInvalidHandle    = errors.New("el nombre de la página debe tener entre 3 y 30 caracteres: letras, números, '.', '-' o '_'")
+	ErrHandleReserved   = errors.New("ese nombre de página está reservado")
+	ErrHandleTaken      = errors.New("ese nombre de página ya está en uso")
+	ErrInvalidPageTitle = errors.New("el título de la página admite hasta 80 caracteres")
+	ErrInvalidPageBio   = errors.New("la descripción de la página admite hasta 300 caracteres")
+	ErrTooManyItems     = errors.New("la página admite hasta 50 enlaces")
+	ErrInvalidItem      = errors.New("cada enlace necesita su código y un título de hasta 80 caracteres")
+	ErrInvalidItemIcon  = errors.New("el ícono debe ser un emoji o texto de hasta 8 caracteres, o la URL https de una imagen")
+	ErrDuplicateItem    = errors.New("un enlace no puede aparecer dos veces en la página")
+	ErrItemNotOwned     = errors.New("la página solo puede incluir enlaces de tu cuenta")
+)
+
+const (
+	maxItemsPerPage   = 50
+	maxPageTitle      = 80
+	maxPageBio        = 300
+	maxItemTitle      = 80
+	maxTextIconLength = 8
+	maxIconURLLength  = 2048
+
+	// Rango de días del resumen de visitas
+	defaultStatsDays = 30
+	maxStatsDays     = 365
+)
+
+// handlePattern se aplica después de pasar el nombre a minúsculas
+var handlePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{2,29}$`)
+
+// reservedHandles evita páginas que se confundan con el propio servicio
+var reservedHandles = map[string]struct{}{
+	"admin":    {},
+	"api":      {},
+	"help":     {},
+	"support":  {},
+	"soporte":  {},
+	"shortgo":  {},
+	"short-go": {},
+	"login":    {},
+	"stats":    {},
+}
+
+// SavePageInput es la configuración completa de la página; Items reemplaza
+// la lista anterior
+type SavePageInput struct {
+	Handle      string
+	Title       string
+	Description string
+	Published   bool
+	Items       []model.BioItem
+}
+
+// PublicItem es un enlace visible de la página con su enlace corto
+type PublicItem struct {
+	model.BioItem
+	ShortLink *shortLinkModel.ShortLink
+}
+
+// BioPageService gestiona las páginas de enlaces de las cuentas y registra
+// sus visitas
+type BioPageService struct {
+	pageRepo      repository.BioPageRepository
+	viewRepo      repository.PageViewRepository
+	shortLinkRepo shortLinkRepo.ShortLinkRepository
+	geoResolver   geoip.Resolver
+	viewChannel   chan *model.PageView
+}
+
+func NewBioPageService(
+	pageRepo repository.BioPageRepository,
+	viewRepo repository.PageViewRepository,
+	shortLinkRepo shortLinkRepo.ShortLinkRepository,
+	geoResolver geoip.Resolver,
+) *BioPageService {
+	s := &BioPageService{
+		pageRepo:      pageRepo,
+		viewRepo:      viewRepo,
+		shortLinkRepo: shortLinkRepo,
+		geoResolver:   geoResolver,
+		// Buffer de 100 visitas, igual que el de clicks
+		viewChannel: make(chan *model.PageView, 100),
+	}
+
+	// Worker en segundo plano
+	go s.processViews()
+
+	return s
+}
+
+// SavePage crea o reemplaza la página del usuario
+func (s *BioPageService) SavePage(userID string, input SavePageInput) (*model.BioPage, error) {
+	handle, err := normalizeHandle(input.Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner, err := s.pageRepo.FindByHandle(handle); err == nil && owner.UserID != userID {
+		return nil, ErrHandleTaken
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if len([]rune(title)) > maxPageTitle {
+		return nil, ErrInvalidPageTitle
+	}
+
+	description := strings.TrimSpace(input.Description)
+	if len([]rune(description)) > maxPageBio {
+		return nil, ErrInvalidPageBio
+	}
+
+	items, err := s.normalizeItems(userID, input.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	page, err := s.pageRepo.FindByUserID(userID)
+	isNew := err != nil
+	if isNew {
+		page = &model.BioPage{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			CreatedAt: now,
+		}
+	}
+
+	page.Handle = handle
+	page.Title = title
+	page.Description = description
+	page.Published = input.Published
+	page.Items = items
+	page.UpdatedAt = now
+
+	if isNew {
+		err = s.pageRepo.Create(page)
+	} else {
+		err = s.pageRepo.Update(page)
+	}
+	if errors.Is(err, repository.ErrDuplicateHandle) {
+		return nil, ErrHandleTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// GetPage - Obtiene la página del usuario, publicada o no
+func (s *BioPageService) GetPage(userID string) (*model.BioPage, error) {
+	page, err := s.pageRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, ErrBioPageNotFound
+	}
+	return page, nil
+}
+
+// DeletePage - Elimina la página del usuario y sus visitas
+func (s *BioPageService) DeletePage(userID string) error {
+	page, err := s.GetPage(userID)
+	if err != nil {
+		return err
+	}
+	return s.pageRepo.Delete(page.ID)
+}
+
+// GetPublicPage obtiene una página publicada con sus enlaces en orden. Los
+// enlaces que hoy no redirigen (eliminados, archivados, deshabilitados,
+// expirados o agotados) no se muestran
+func (s *BioPageService) GetPublicPage(handle string) (*model.BioPage, []PublicItem, error) {
+	page, err := s.pageRepo.FindByHandle(strings.ToLower(handle))
+	if err != nil || !page.Published {
+		return nil, nil, ErrBioPageNotFound
+	}
+
+	links, err := s.findLinks(page.Items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]PublicItem, 0, len(page.Items))
+	for _, item := range page.Items {
+		link, ok := links[item.LinkCode]
+		if !ok || link.UserID == nil || *link.UserID != page.UserID {
+			continue
+		}
+		if link.IsDisabled() || link.IsArchived() || link.IsExpired() || link.ClickLimitReached() {
+			continue
+		}
+		items = append(items, PublicItem{BioItem: item, ShortLink: link})
+	}
+
+	return page, items, nil
+}
+
+// TrackView encola una visita a la página para guardarla en segundo plano
+func (s *BioPageService) TrackView(view *model.PageView, ip string) {
+	if view.ViewedAt.IsZero() {
+		view.ViewedAt = time.Now()
+	}
+	view.CountryCode = s.geoResolver.CountryCode(ip)
+
+	select {
+	case s.viewChannel <- view:
+	default:
+		log.Println("Warning: buffer de visitas lleno, se descarta una visita")
+	}
+}
+
+func (s *BioPageService) processViews() {
+	for view := range s.viewChannel {
+		if err := s.viewRepo.Save(view); err != nil {
+			log.Printf("Error guardando la visita de la página: %v", err)
+		}
+	}
+}
+
+// GetStats resume las visitas de la página del usuario en los últimos días
+func (s *BioPageService) GetStats(userID string, days int) (*model.PageStats, error) {
+	page, err := s.GetPage(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if days < 1 {
+		days = defaultStatsDays
+	}
+	if days > maxStatsDays {
+		days = maxStatsDays
+	}
+
+	return s.viewRepo.GetPageStats(page.ID, time.Now().AddDate(0, 0, -days))
+}
+
+// normalizeItems valida los enlaces de la página, que deben ser del usuario,
+// y los ordena por posición renumerándolos desde 1. Sin título, un enlace
+// usa el suyo propio o su código
+func (s *BioPageService) normalizeItems(userID string, items []model.BioItem) ([]model.BioItem, error) {
+	if len(items) > maxItemsPerPage {
+		return nil, ErrTooManyItems
+	}
+
+	seen := make(map[string]bool, len(items))
+	for i := range items {
+		items[i].LinkCode = strings.TrimSpace(items[i].LinkCode)
+		if items[i].LinkCode == "" {
+			return nil, ErrInvalidItem
+		}
+		if seen[items[i].LinkCode] {
+			return nil, ErrDuplicateItem
+		}
+		seen[items[i].LinkCode] = true
+	}
+
+	links, err := s.findLinks(items)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]model.BioItem, len(items))
+	for i, item := range items {
+		link, ok := links[item.LinkCode]
+		if !ok || link.UserID == nil || *link.UserID != userID {
+			return nil, ErrItemNotOwned
+		}
+
+		item.Title = strings.TrimSpace(item.Title)
+		if item.Title == "" {
+			item.Title = link.Title
+		}
+		if item.Title == "" {
+			item.Title = link.Code
+		}
+		if len([]rune(item.Title)) > maxItemTitle {
+			return nil, ErrInvalidItem
+		}
+
+		icon, err := normalizeIcon(item.Icon)
+		if err != nil {
+			return nil, err
+		}
+		item.Icon = icon
+
+		normalized[i] = item
+	}
+
+	// Mismo valor de posición: se respeta el orden recibido
+	sort.SliceStable(normalized, func(i, j int) bool { return normalized[i].Position < normalized[j].Position })
+	for i := range normalized {
+		normalized[i].Position = i + 1
+	}
+
+	return normalized, nil
+}
+
+// findLinks obtiene los enlaces de los items indexados por código
+func (s *BioPageService) findLinks(items []model.BioItem) (map[string]*shortLinkModel.ShortLink, error) {
+	codes := make([]string, len(items))
+	for i, item := range items {
+		codes[i] = item.LinkCode
+	}
+
+	links, err := s.shortLinkRepo.FindByCodes(codes)
+	if err != nil {
+		return nil, err
+	}
+
+	byCode := make(map[string]*shortLinkModel.ShortLink, len(links))
+	for _, link := range links {
+		byCode[link.Code] = link
+	}
+	return byCode, nil
+}
+
+func normalizeHandle(handle string) (string, error) {
+	handle = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(handle), "@")))
+	if !handlePattern.MatchString(handle) {
+		return "", ErrInvalidHandle
+	}
+	if _, reserved := reservedHandles[handle]; reserved {
+		return "", ErrHandleReserved
+	}
+	return handle, nil
+}
+
+// normalizeIcon acepta un emoji o texto corto, o la URL https de una imagen
+func normalizeIcon(icon string) (string, error) {
+	icon = strings.TrimSpace(icon)
+	if icon == "" {
+		return "", nil
+	}
+
+	if strings.HasPrefix(strings.ToLower(icon), "https://") {
+		parsed, err := url.Parse(icon)
+		if err != nil || parsed.Hostname() == "" || parsed.User != nil || len(icon) > maxIconURLLength {
+			return "", ErrInvalidItemIcon
+		}
+		return parsed.String(), nil
+	}
+
+	if strings.Contains(icon, "://") || len([]rune(icon)) > maxTextIconLength {
+		return "", ErrInvalidItemIcon
+	}
+	return icon, nil
+}
+
+// IsImageIcon indica si el ícono es una imagen en lugar de texto
+func IsImageIcon(icon string) bool {
+	return strings.HasPrefix(icon, "https://")
+}
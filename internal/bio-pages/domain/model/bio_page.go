@@ -0,0 +1,47 @@
+package model
+
+import (
+	analyticsModel "short-go/internal/analytics/domain/model"
+	"time"
+)
+
+// BioPage es la página pública de una cuenta (/@handle) con una selección
+// de sus enlaces. Cada cuenta tiene como máximo una
+type BioPage struct {
+	ID          string `json:"id"`
+	UserID      string `json:"userId"`
+	Handle      string `json:"handle"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Una página sin publicar solo la ve su dueño desde la API
+	Published bool      `json:"published"`
+	Items     []BioItem `json:"items"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// BioItem es un enlace de la página. Position define el orden (de menor a
+// mayor); Icon es un emoji o texto corto, o la URL https de una imagen
+type BioItem struct {
+	LinkCode string `json:"code"`
+	Title    string `json:"title"`
+	Icon     string `json:"icon,omitempty"`
+	Position int    `json:"position"`
+}
+
+// PageView es una visita a la página pública
+type PageView struct {
+	PageID      string
+	Referrer    string
+	CountryCode string
+	ViewedAt    time.Time
+}
+
+// PageStats resume las visitas de una página; los clicks en sus enlaces se
+// cuentan en las estadísticas de cada enlace
+type PageStats struct {
+	TotalViews   int64                         `json:"totalViews"`
+	ViewsByDate  []analyticsModel.DailyStat    `json:"viewsByDate"`
+	TopCountries []analyticsModel.CountryStat  `json:"topCountries"`
+	TopReferrers []analyticsModel.ReferrerStat `json:"topReferrers"`
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"errors"
+	"short-go/internal/bio-pages/domain/model"
+)
+
+// ErrDuplicateHandle indica que otra página ya tiene el handle
+var ErrDuplicateHandle = errors.New("el handle de la página ya existe")
+
+type BioPageRepository interface {
+	Create(page *model.BioPage) error
+	Update(page *model.BioPage) error
+	FindByUserID(userID string) (*model.BioPage, error)
+	FindByHandle(handle string) (*model.BioPage, error)
+	// Delete elimina la página junto con sus visitas
+	Delete(id string) error
+}
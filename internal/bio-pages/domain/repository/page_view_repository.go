@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"short-go/internal/bio-pages/domain/model"
+	"time"
+)
+
+type PageViewRepository interface {
+	Save(view *model.PageView) error
+	// GetPageStats resume las visitas de la página desde since
+	GetPageStats(pageID string, since time.Time) (*model.PageStats, error)
+}
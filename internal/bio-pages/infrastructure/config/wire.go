@@ -0,0 +1,53 @@
+package config
+
+import (
+	"short-go/config"
+	"short-go/internal/bio-pages/application/service"
+	"short-go/internal/bio-pages/infrastructure/http/handler"
+	gormRepo "short-go/internal/bio-pages/infrastructure/persistence/gorm"
+	"short-go/internal/shared/geoip"
+	"short-go/internal/shared/infrastructure/middleware"
+	shortLinkRepo "short-go/internal/short-links/domain/repository"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+type BioPagesModule struct {
+	Handler *handler.BioPageHandler
+}
+
+func NewBioPagesModule(
+	db *gorm.DB,
+	cfg *config.Config,
+	linkRepo shortLinkRepo.ShortLinkRepository,
+	geoResolver geoip.Resolver,
+) *BioPagesModule {
+	// Repositories
+	pageRepo := gormRepo.NewBioPageRepository(db)
+	viewRepo := gormRepo.NewPageViewRepository(db)
+
+	// Services
+	bioPageService := service.NewBioPageService(pageRepo, viewRepo, linkRepo, geoResolver)
+
+	// Handlers
+	bioPageHandler := handler.NewBioPageHandler(bioPageService, cfg)
+
+	return &BioPagesModule{
+		Handler: bioPageHandler,
+	}
+}
+
+// RegisterRoutes registra las rutas del módulo bio-pages
+func (m *BioPagesModule) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	r.Route("/api/bio-page", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Get("/", m.Handler.GetPage)
+		r.Put("/", m.Handler.SavePage)
+		r.Delete("/", m.Handler.DeletePage)
+		r.Get("/stats", m.Handler.GetStats)
+	})
+
+	// Página pública: /@handle no choca con los códigos de /{code}
+	r.Get("/@{handle}", m.Handler.RenderPage)
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"short-go/config"
+	"short-go/internal/bio-pages/application/service"
+	"short-go/internal/bio-pages/domain/model"
+	sharedContext "short-go/internal/shared/context"
+	sharedhttp "short-go/internal/shared/http"
+	format "short-go/internal/shared/http/utils"
+	sharedValidation "short-go/internal/shared/validation"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type BioPageHandler struct {
+	bioPageService *service.BioPageService
+	validator      *validator.Validate
+	config         *config.Config
+}
+
+func NewBioPageHandler(bioPageService *service.BioPageService, cfg *config.Config) *BioPageHandler {
+	return &BioPageHandler{
+		bioPageService: bioPageService,
+		validator:      sharedValidation.NewValidator(),
+		config:         cfg,
+	}
+}
+
+type BioItemRequest struct {
+	Code     string `json:"code" validate:"required,max=32"`
+	Title    string `json:"title,omitempty"`
+	Icon     string `json:"icon,omitempty"`
+	Position int    `json:"position"`
+}
+
+// SavePageRequest reemplaza la página completa, incluida su lista de enlaces
+type SavePageRequest struct {
+	Handle      string           `json:"handle" validate:"required"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Published   bool             `json:"published"`
+	Items       []BioItemRequest `json:"items" validate:"dive"`
+}
+
+type BioPageResponse struct {
+	Handle      string          `json:"handle"`
+	URL         string          `json:"url"`
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Published   bool            `json:"published"`
+	Items       []model.BioItem `json:"items"`
+	CreatedAt   string          `json:"createdAt"`
+	UpdatedAt   string          `json:"updatedAt"`
+}
+
+// SavePage - PUT /api/bio-page
+func (h *BioPageHandler) SavePage(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req SavePageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	items := make([]model.BioItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = model.BioItem{
+			LinkCode: item.Code,
+			Title:    item.Title,
+			Icon:     item.Icon,
+			Position: item.Position,
+		}
+	}
+
+	page, err := h.bioPageService.SavePage(userID, service.SavePageInput{
+		Handle:      req.Handle,
+		Title:       req.Title,
+		Description: req.Description,
+		Published:   req.Published,
+		Items:       items,
+	})
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, h.toResponse(page))
+}
+
+// GetPage - GET /api/bio-page
+func (h *BioPageHandler) GetPage(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	page, err := h.bioPageService.GetPage(userID)
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, h.toResponse(page))
+}
+
+// DeletePage - DELETE /api/bio-page
+func (h *BioPageHandler) DeletePage(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	if err := h.bioPageService.DeletePage(userID); err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, map[string]string{"message": "Página eliminada"})
+}
+
+// GetStats - GET /api/bio-page/stats?days=30
+func (h *BioPageHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	days := 0
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			sharedhttp.ErrorResponse(w, http.StatusBadRequest, "days debe ser un número entero positivo")
+			return
+		}
+		days = parsed
+	}
+
+	stats, err := h.bioPageService.GetStats(userID, days)
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, stats)
+}
+
+// RenderPage - GET /@{handle}
+// Página pública con los enlaces en orden. Cada enlace apunta a su URL corta,
+// así los clicks pasan por la redirección y se cuentan en sus estadísticas
+func (h *BioPageHandler) RenderPage(w http.ResponseWriter, r *http.Request) {
+	page, items, err := h.bioPageService.GetPublicPage(chi.URLParam(r, "handle"))
+	if err != nil {
+		if err == service.ErrBioPageNotFound {
+			sharedhttp.RenderMessagePage(w, http.StatusNotFound, "Página no encontrada",
+				"Esta página no existe o todavía no fue publicada.")
+			return
+		}
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al cargar la página")
+		return
+	}
+
+	h.bioPageService.TrackView(&model.PageView{
+		PageID:   page.ID,
+		Referrer: r.Referer(),
+	}, sharedhttp.ClientIP(r))
+
+	view := publicPage{
+		Handle:      page.Handle,
+		Title:       page.Title,
+		Description: page.Description,
+		Items:       make([]publicPageItem, len(items)),
+	}
+	if view.Title == "" {
+		view.Title = "@" + page.Handle
+	}
+	for i, item := range items {
+		view.Items[i] = publicPageItem{
+			Title: item.Title,
+			URL:   fmt.Sprintf("%s/%s", sharedhttp.ShortURLBase(h.config, item.ShortLink.Domain), item.ShortLink.Code),
+		}
+		if service.IsImageIcon(item.Icon) {
+			view.Items[i].IconURL = item.Icon
+		} else {
+			view.Items[i].Icon = item.Icon
+		}
+	}
+
+	renderBioPage(w, view)
+}
+
+// toResponse construye la respuesta de la página para su dueño
+func (h *BioPageHandler) toResponse(page *model.BioPage) BioPageResponse {
+	items := page.Items
+	if items == nil {
+		items = []model.BioItem{}
+	}
+
+	return BioPageResponse{
+		Handle:      page.Handle,
+		URL:         fmt.Sprintf("%s/@%s", sharedhttp.BaseURL(h.config), page.Handle),
+		Title:       page.Title,
+		Description: page.Description,
+		Published:   page.Published,
+		Items:       items,
+		CreatedAt:   page.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   page.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// manageErrorResponse traduce errores del servicio a códigos HTTP
+func (h *BioPageHandler) manageErrorResponse(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch err {
+	case service.ErrBioPageNotFound:
+		status = http.StatusNotFound
+	case service.ErrInvalidHandle, service.ErrHandleReserved,
+		service.ErrInvalidPageTitle, service.ErrInvalidPageBio,
+		service.ErrTooManyItems, service.ErrInvalidItem, service.ErrInvalidItemIcon,
+		service.ErrDuplicateItem, service.ErrItemNotOwned:
+		status = http.StatusBadRequest
+	case service.ErrHandleTaken:
+		status = http.StatusConflict
+	}
+
+	sharedhttp.ErrorResponse(w, status, err.Error())
+}
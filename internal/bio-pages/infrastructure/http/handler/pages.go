@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// bioPageTemplate es la página pública con los enlaces de la cuenta
+var bioPageTemplate = template.Must(template.New("bio").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} · ShortGo</title>
+{{if .Description}}<meta name="description" content="{{.Description}}">{{end}}
+<style>
+body{font-family:system-ui,sans-serif;background:#f5f5f7;color:#1d1d1f;margin:0;padding:2rem 1rem}
+main{max-width:32rem;margin:0 auto;text-align:center}
+h1{font-size:1.5rem;margin-bottom:.25rem}
+.handle{color:#888;margin-top:0}
+.description{line-height:1.5;color:#444;white-space:pre-line}
+ul{list-style:none;padding:0;margin:1.5rem 0 0}
+li{margin-bottom:.75rem}
+a.item{display:flex;align-items:center;gap:.75rem;background:#fff;border-radius:12px;padding:.9rem 1.2rem;box-shadow:0 2px 12px rgba(0,0,0,.08);color:#1d1d1f;text-decoration:none;font-weight:500}
+a.item:hover{box-shadow:0 4px 16px rgba(0,0,0,.14)}
+.icon{width:1.6rem;height:1.6rem;flex:none;font-size:1.3rem;line-height:1.6rem;object-fit:cover;border-radius:6px}
+.title{flex:1;word-break:break-word}
+.empty{color:#888}
+</style>
+</head>
+<body>
+<main>
+<h1>{{.Title}}</h1>
+<p class="handle">@{{.Handle}}</p>
+{{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+{{if .Items}}<ul>
+{{range .Items}}<li><a class="item" href="{{.URL}}" rel="noopener">{{if .IconURL}}<img class="icon" src="{{.IconURL}}" alt="" loading="lazy">{{else if .Icon}}<span class="icon">{{.Icon}}</span>{{end}}<span class="title">{{.Title}}</span></a></li>
+{{end}}</ul>{{else}}<p class="empty">Esta página todavía no tiene enlaces.</p>{{end}}
+</main>
+</body>
+</html>`))
+
+type publicPage struct {
+	Handle      string
+	Title       string
+	Description string
+	Items       []publicPageItem
+}
+
+type publicPageItem struct {
+	Title   string
+	URL     string
+	Icon    string
+	IconURL string
+}
+
+// renderBioPage responde con la página pública. No se cachea para que cada
+// visita se cuente y los cambios se vean al instante
+func renderBioPage(w http.ResponseWriter, page publicPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	bioPageTemplate.Execute(w, page)
+}
@@ -0,0 +1,121 @@
+package gorm
+
+import (
+	"errors"
+	"short-go/internal/bio-pages/domain/model"
+	"short-go/internal/bio-pages/domain/repository"
+	derefUtils "short-go/internal/shared/http/utils"
+
+	"gorm.io/gorm"
+)
+
+// editableColumns son las columnas que Update sobrescribe
+var editableColumns = []string{
+	"handle",
+	"title",
+	"description",
+	"published",
+	"items",
+	"updated_at",
+}
+
+type BioPageRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewBioPageRepository(db *gorm.DB) repository.BioPageRepository {
+	return &BioPageRepositoryGorm{db: db}
+}
+
+func (r *BioPageRepositoryGorm) Create(page *model.BioPage) error {
+	return translateDuplicate(r.db.Create(toModel(page)).Error)
+}
+
+func (r *BioPageRepositoryGorm) Update(page *model.BioPage) error {
+	return translateDuplicate(r.db.Model(&BioPageModel{}).
+		Where("id = ?", page.ID).
+		Select(editableColumns).
+		Updates(toModel(page)).Error)
+}
+
+func (r *BioPageRepositoryGorm) FindByUserID(userID string) (*model.BioPage, error) {
+	var pageModel BioPageModel
+	if err := r.db.Where("user_id = ?", userID).First(&pageModel).Error; err != nil {
+		return nil, err
+	}
+	return toDomain(&pageModel), nil
+}
+
+func (r *BioPageRepositoryGorm) FindByHandle(handle string) (*model.BioPage, error) {
+	var pageModel BioPageModel
+	if err := r.db.Where("handle = ?", handle).First(&pageModel).Error; err != nil {
+		return nil, err
+	}
+	return toDomain(&pageModel), nil
+}
+
+func (r *BioPageRepositoryGorm) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("page_id = ?", id).Delete(&PageViewModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&BioPageModel{}).Error
+	})
+}
+
+// toModel convierte model.BioPage -> BioPageModel
+func toModel(page *model.BioPage) *BioPageModel {
+	items := make([]BioItemModel, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = BioItemModel(item)
+	}
+
+	return &BioPageModel{
+		ID:          page.ID,
+		UserID:      page.UserID,
+		Handle:      page.Handle,
+		Title:       nullableString(page.Title),
+		Description: nullableString(page.Description),
+		Published:   page.Published,
+		Items:       items,
+		CreatedAt:   page.CreatedAt,
+		UpdatedAt:   page.UpdatedAt,
+	}
+}
+
+// toDomain convierte BioPageModel -> model.BioPage
+func toDomain(pageModel *BioPageModel) *model.BioPage {
+	items := make([]model.BioItem, len(pageModel.Items))
+	for i, item := range pageModel.Items {
+		items[i] = model.BioItem(item)
+	}
+
+	return &model.BioPage{
+		ID:          pageModel.ID,
+		UserID:      pageModel.UserID,
+		Handle:      pageModel.Handle,
+		Title:       derefUtils.DerefString(pageModel.Title),
+		Description: derefUtils.DerefString(pageModel.Description),
+		Published:   pageModel.Published,
+		Items:       items,
+		CreatedAt:   pageModel.CreatedAt,
+		UpdatedAt:   pageModel.UpdatedAt,
+	}
+}
+
+// translateDuplicate convierte la violación del índice único en
+// ErrDuplicateHandle: otro usuario tomó el handle entre la verificación
+// del servicio y la escritura
+func translateDuplicate(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return repository.ErrDuplicateHandle
+	}
+	return err
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
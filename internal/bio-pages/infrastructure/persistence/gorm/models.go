@@ -0,0 +1,41 @@
+package gorm
+
+import "time"
+
+// BioPageModel representa la tabla bio_pages
+type BioPageModel struct {
+	ID          string         `gorm:"primaryKey;type:text"`
+	UserID      string         `gorm:"not null;uniqueIndex"`
+	Handle      string         `gorm:"size:30;not null;uniqueIndex"`
+	Title       *string        `gorm:"type:text"`
+	Description *string        `gorm:"type:text"`
+	Published   bool           `gorm:"not null;default:false"`
+	Items       []BioItemModel `gorm:"type:jsonb;serializer:json"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
+}
+
+func (BioPageModel) TableName() string {
+	return "bio_pages"
+}
+
+// BioItemModel es la forma persistida (JSON) de un enlace de la página
+type BioItemModel struct {
+	LinkCode string `json:"code"`
+	Title    string `json:"title"`
+	Icon     string `json:"icon,omitempty"`
+	Position int    `json:"position"`
+}
+
+// PageViewModel representa la tabla bio_page_views
+type PageViewModel struct {
+	ID          int       `gorm:"primaryKey;autoIncrement"`
+	PageID      string    `gorm:"not null;index"`
+	Referrer    string    `gorm:"type:text"`
+	CountryCode string    `gorm:"size:2"`
+	ViewedAt    time.Time `gorm:"autoCreateTime;index"`
+}
+
+func (PageViewModel) TableName() string {
+	return "bio_page_views"
+}
@@ -0,0 +1,85 @@
+package gorm
+
+import (
+	analyticsModel "short-go/internal/analytics/domain/model"
+	"short-go/internal/bio-pages/domain/model"
+	"short-go/internal/bio-pages/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Cantidad de países y referidos en el resumen
+const topStatsLimit = 5
+
+type PageViewRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewPageViewRepository(db *gorm.DB) repository.PageViewRepository {
+	return &PageViewRepositoryGorm{db: db}
+}
+
+func (r *PageViewRepositoryGorm) Save(view *model.PageView) error {
+	return r.db.Create(&PageViewModel{
+		PageID:      view.PageID,
+		Referrer:    view.Referrer,
+		CountryCode: view.CountryCode,
+		ViewedAt:    view.ViewedAt,
+	}).Error
+}
+
+func (r *PageViewRepositoryGorm) GetPageStats(pageID string, since time.Time) (*model.PageStats, error) {
+	views := func() *gorm.DB {
+		return r.db.Model(&PageViewModel{}).Where("page_id = ? AND viewed_at >= ?", pageID, since)
+	}
+
+	stats := &model.PageStats{}
+
+	if err := views().Count(&stats.TotalViews).Error; err != nil {
+		return nil, err
+	}
+
+	err := views().
+		Select("TO_CHAR(viewed_at, 'YYYY-MM-DD') as date, COUNT(*) as count").
+		Group("TO_CHAR(viewed_at, 'YYYY-MM-DD')").
+		Order("date ASC").
+		Scan(&stats.ViewsByDate).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = views().
+		Select("country_code, COUNT(*) as count").
+		Group("country_code").
+		Order("count DESC").
+		Limit(topStatsLimit).
+		Scan(&stats.TopCountries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = views().
+		Select("referrer, COUNT(*) as count").
+		Where("referrer <> ''").
+		Group("referrer").
+		Order("count DESC").
+		Limit(topStatsLimit).
+		Scan(&stats.TopReferrers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Listas vacías en lugar de null en el JSON
+	if stats.ViewsByDate == nil {
+		stats.ViewsByDate = []analyticsModel.DailyStat{}
+	}
+	if stats.TopCountries == nil {
+		stats.TopCountries = []analyticsModel.CountryStat{}
+	}
+	if stats.TopReferrers == nil {
+		stats.TopReferrers = []analyticsModel.ReferrerStat{}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net"
+	"short-go/internal/domains/domain/model"
+	"short-go/internal/domains/domain/repository"
+	"short-go/internal/shared/urlutil"
+	shortLinkRepo "short-go/internal/short-links/domain/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDomainNotFound     = errors.New("dominio no encontrado")
+	ErrInvalidDomainHost  = errors.New("dominio inválido: usa un nombre completo como go.tumarca.com")
+	ErrDomainReserved     = errors.New("el dominio pertenece al acortador y no se puede registrar")
+	ErrDomainAlreadyAdded = errors.New("el dominio ya está registrado en tu cuenta")
+	ErrDomainTaken        = errors.New("el dominio ya fue verificado por otra cuenta")
+	ErrDomainLimitReached = errors.New("se pueden registrar hasta 20 dominios por cuenta")
+	ErrVerificationFailed = errors.New("no se encontró el registro TXT de verificación")
+	ErrDNSLookupFailed    = errors.New("no se pudo consultar el DNS del dominio, intenta nuevamente")
+)
+
+const (
+	maxDomainsPerUser         = 20
+	verificationTokenLength   = 16
+	verificationLookupTimeout = 5 * time.Second
+)
+
+// TXTResolver consulta registros TXT. net.DefaultResolver la implementa; las
+// pruebas usan un resolver falso
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// DomainService registra y verifica los dominios personalizados de una cuenta
+type DomainService struct {
+	domainRepo    repository.DomainRepository
+	shortLinkRepo shortLinkRepo.ShortLinkRepository
+	resolver      TXTResolver
+	lookupTimeout time.Duration
+	// Dominios del propio acortador: ni ellos ni sus subdominios se registran
+	reservedHosts []string
+}
+
+func NewDomainService(
+	domainRepo repository.DomainRepository,
+	shortLinkRepo shortLinkRepo.ShortLinkRepository,
+	resolver TXTResolver,
+	reservedHosts []string,
+) *DomainService {
+	s := &DomainService{
+		domainRepo:    domainRepo,
+		shortLinkRepo: shortLinkRepo,
+		resolver:      resolver,
+		lookupTimeout: verificationLookupTimeout,
+	}
+	for _, host := range reservedHosts {
+		if normalized, err := urlutil.NormalizeHost(host); err == nil {
+			s.reservedHosts = append(s.reservedHosts, normalized)
+		}
+	}
+	return s
+}
+
+// AddDomain registra un dominio pendiente de verificación con un token nuevo
+func (s *DomainService) AddDomain(userID string, rawHost string) (*model.Domain, error) {
+	host, err := s.normalizeDomainHost(rawHost)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, err := s.domainRepo.FindByUserAndHost(userID, host); err == nil && existing != nil {
+		return nil, ErrDomainAlreadyAdded
+	}
+
+	count, err := s.domainRepo.CountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxDomainsPerUser {
+		return nil, ErrDomainLimitReached
+	}
+
+	token, err := generateVerificationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := &model.Domain{
+		ID:                uuid.New().String(),
+		UserID:            userID,
+		Host:              host,
+		VerificationToken: token,
+		CreatedAt:         time.Now(),
+	}
+	if err := s.domainRepo.Create(domain); err != nil {
+		return nil, err
+	}
+
+	return domain, nil
+}
+
+// ListDomains - Dominios de la cuenta, verificados o pendientes
+func (s *DomainService) ListDomains(userID string) ([]*model.Domain, error) {
+	return s.domainRepo.FindByUserID(userID)
+}
+
+// GetDomain - Obtiene un dominio de la cuenta
+func (s *DomainService) GetDomain(userID string, id string) (*model.Domain, error) {
+	domain, err := s.domainRepo.FindByID(id)
+	if err != nil || domain.UserID != userID {
+		return nil, ErrDomainNotFound
+	}
+	return domain, nil
+}
+
+// VerifyDomain busca el registro TXT del dominio y, si contiene el token,
+// lo marca como verificado. Verificar un dominio ya verificado no hace nada
+func (s *DomainService) VerifyDomain(userID string, id string) (*model.Domain, error) {
+	domain, err := s.GetDomain(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if domain.IsVerified() {
+		return domain, nil
+	}
+
+	if owner, err := s.domainRepo.FindVerifiedByHost(domain.Host); err == nil && owner.UserID != userID {
+		return nil, ErrDomainTaken
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.lookupTimeout)
+	defer cancel()
+
+	records, err := s.resolver.LookupTXT(ctx, domain.VerificationRecord())
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, ErrVerificationFailed
+		}
+		log.Printf("Error consultando el TXT de %s: %v", domain.Host, err)
+		return nil, ErrDNSLookupFailed
+	}
+
+	if !containsRecord(records, domain.VerificationValue()) {
+		return nil, ErrVerificationFailed
+	}
+
+	now := time.Now()
+	if err := s.domainRepo.MarkVerified(domain.ID, now); err != nil {
+		// Otra cuenta pudo verificar el mismo host al mismo tiempo
+		if verified, findErr := s.domainRepo.IsVerifiedHost(domain.Host); findErr == nil && verified {
+			return nil, ErrDomainTaken
+		}
+		return nil, err
+	}
+
+	domain.VerifiedAt = &now
+	return domain, nil
+}
+
+// DeleteDomain elimina un dominio de la cuenta. Sus enlaces vuelven al
+// dominio compartido, donde siguen funcionando con el mismo código
+func (s *DomainService) DeleteDomain(userID string, id string) error {
+	domain, err := s.GetDomain(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if domain.IsVerified() {
+		if _, err := s.shortLinkRepo.DetachDomain(userID, domain.Host); err != nil {
+			return err
+		}
+	}
+
+	return s.domainRepo.Delete(domain.ID)
+}
+
+// normalizeDomainHost exige un nombre de dominio completo que no sea una IP
+// ni un dominio del acortador
+func (s *DomainService) normalizeDomainHost(rawHost string) (string, error) {
+	if net.ParseIP(strings.Trim(strings.TrimSpace(rawHost), "[]")) != nil {
+		return "", ErrInvalidDomainHost
+	}
+
+	host, err := urlutil.NormalizeHost(rawHost)
+	if err != nil || !strings.Contains(host, ".") {
+		return "", ErrInvalidDomainHost
+	}
+
+	for _, reserved := range s.reservedHosts {
+		if host == reserved || strings.HasSuffix(host, "."+reserved) {
+			return "", ErrDomainReserved
+		}
+	}
+
+	return host, nil
+}
+
+// containsRecord compara ignorando espacios y mayúsculas del registro, que
+// algunos paneles de DNS alteran
+func containsRecord(records []string, expected string) bool {
+	for _, record := range records {
+		if strings.EqualFold(strings.TrimSpace(record), expected) {
+			return true
+		}
+	}
+	return false
+}
+
+func generateVerificationToken() (string, error) {
+	bytes := make([]byte, verificationTokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
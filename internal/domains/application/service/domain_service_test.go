@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"short-go/internal/domains/domain/model"
+	"testing"
+	"time"
+)
+
+// fakeResolver responde con registros fijos, con un error o, si block es
+// true, espera a que venza el contexto como un DNS que no responde
+type fakeResolver struct {
+	records []string
+	err     error
+	block   bool
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if r.block {
+		<-ctx.Done()
+		return nil, &net.DNSError{Err: ctx.Err().Error(), Name: name, IsTimeout: true}
+	}
+	return r.records, r.err
+}
+
+// fakeDomainRepository guarda los dominios en memoria
+type fakeDomainRepository struct {
+	domains map[string]*model.Domain
+}
+
+func newFakeDomainRepository(domains ...*model.Domain) *fakeDomainRepository {
+	repo := &fakeDomainRepository{domains: make(map[string]*model.Domain)}
+	for _, domain := range domains {
+		repo.domains[domain.ID] = domain
+	}
+	return repo
+}
+
+func (r *fakeDomainRepository) Create(domain *model.Domain) error {
+	r.domains[domain.ID] = domain
+	return nil
+}
+
+func (r *fakeDomainRepository) FindByID(id string) (*model.Domain, error) {
+	domain, ok := r.domains[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	copied := *domain
+	return &copied, nil
+}
+
+func (r *fakeDomainRepository) FindByUserID(userID string) ([]*model.Domain, error) {
+	var domains []*model.Domain
+	for _, domain := range r.domains {
+		if domain.UserID == userID {
+			domains = append(domains, domain)
+		}
+	}
+	return domains, nil
+}
+
+func (r *fakeDomainRepository) FindByUserAndHost(userID string, host string) (*model.Domain, error) {
+	for _, domain := range r.domains {
+		if domain.UserID == userID && domain.Host == host {
+			return domain, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *fakeDomainRepository) FindVerifiedByHost(host string) (*model.Domain, error) {
+	for _, domain := range r.domains {
+		if domain.Host == host && domain.IsVerified() {
+			return domain, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *fakeDomainRepository) IsVerifiedHost(host string) (bool, error) {
+	_, err := r.FindVerifiedByHost(host)
+	return err == nil, nil
+}
+
+func (r *fakeDomainRepository) CountByUserID(userID string) (int64, error) {
+	domains, _ := r.FindByUserID(userID)
+	return int64(len(domains)), nil
+}
+
+func (r *fakeDomainRepository) MarkVerified(id string, at time.Time) error {
+	r.domains[id].VerifiedAt = &at
+	return nil
+}
+
+func (r *fakeDomainRepository) Delete(id string) error {
+	delete(r.domains, id)
+	return nil
+}
+
+func TestVerifyDomain(t *testing.T) {
+	pending := &model.Domain{
+		ID:                "domain-1",
+		UserID:            "user-1",
+		Host:              "go.tumarca.com",
+		VerificationToken: "abc123",
+	}
+
+	tests := []struct {
+		name         string
+		resolver     *fakeResolver
+		wantErr      error
+		wantVerified bool
+	}{
+		{
+			name:         "registro encontrado",
+			resolver:     &fakeResolver{records: []string{"v=spf1 -all", " Short-Go-Verification=abc123 "}},
+			wantVerified: true,
+		},
+		{
+			name:     "registro con otro token",
+			resolver: &fakeResolver{records: []string{"short-go-verification=otro"}},
+			wantErr:  ErrVerificationFailed,
+		},
+		{
+			name:     "NXDOMAIN",
+			resolver: &fakeResolver{err: &net.DNSError{Err: "no such host", Name: pending.VerificationRecord(), IsNotFound: true}},
+			wantErr:  ErrVerificationFailed,
+		},
+		{
+			name:     "timeout",
+			resolver: &fakeResolver{block: true},
+			wantErr:  ErrDNSLookupFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain := *pending
+			repo := newFakeDomainRepository(&domain)
+			s := NewDomainService(repo, nil, tt.resolver, nil)
+			s.lookupTimeout = 20 * time.Millisecond
+
+			got, err := s.VerifyDomain("user-1", domain.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, se esperaba %v", err, tt.wantErr)
+			}
+
+			stored, _ := repo.FindByID(domain.ID)
+			if stored.IsVerified() != tt.wantVerified {
+				t.Errorf("verificado = %v, se esperaba %v", stored.IsVerified(), tt.wantVerified)
+			}
+			if tt.wantVerified && (got == nil || !got.IsVerified()) {
+				t.Errorf("el dominio devuelto no quedó verificado")
+			}
+		})
+	}
+}
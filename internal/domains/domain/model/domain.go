@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// Registro TXT con el que se demuestra el control de un dominio:
+// _short-go.<host> con el valor short-go-verification=<token>
+const (
+	VerificationRecordPrefix = "_short-go."
+	VerificationValuePrefix  = "short-go-verification="
+)
+
+// Domain es un dominio personalizado de una cuenta. Solo sirve enlaces una
+// vez verificado
+type Domain struct {
+	ID     string `json:"id"`
+	UserID string `json:"userId"`
+	// En minúsculas y Punycode, sin esquema ni puerto
+	Host              string     `json:"host"`
+	VerificationToken string     `json:"-"`
+	VerifiedAt        *time.Time `json:"verifiedAt,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+}
+
+func (d *Domain) IsVerified() bool {
+	return d.VerifiedAt != nil
+}
+
+// VerificationRecord es el nombre del registro TXT que se consulta
+func (d *Domain) VerificationRecord() string {
+	return VerificationRecordPrefix + d.Host
+}
+
+// VerificationValue es el contenido esperado del registro TXT
+func (d *Domain) VerificationValue() string {
+	return VerificationValuePrefix + d.VerificationToken
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"short-go/internal/domains/domain/model"
+	"time"
+)
+
+type DomainRepository interface {
+	Create(domain *model.Domain) error
+	FindByID(id string) (*model.Domain, error)
+	FindByUserID(userID string) ([]*model.Domain, error)
+	FindByUserAndHost(userID string, host string) (*model.Domain, error)
+	// FindVerifiedByHost busca la cuenta que verificó el dominio
+	FindVerifiedByHost(host string) (*model.Domain, error)
+	IsVerifiedHost(host string) (bool, error)
+	CountByUserID(userID string) (int64, error)
+	MarkVerified(id string, at time.Time) error
+	Delete(id string) error
+}
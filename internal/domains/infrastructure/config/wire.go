@@ -0,0 +1,45 @@
+package config
+
+import (
+	"net"
+	"short-go/config"
+	"short-go/internal/domains/application/service"
+	"short-go/internal/domains/domain/repository"
+	"short-go/internal/domains/infrastructure/http/handler"
+	"short-go/internal/shared/infrastructure/middleware"
+	shortLinkRepo "short-go/internal/short-links/domain/repository"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type DomainsModule struct {
+	Handler *handler.DomainHandler
+}
+
+func NewDomainsModule(
+	cfg *config.Config,
+	domainRepo repository.DomainRepository,
+	linkRepo shortLinkRepo.ShortLinkRepository,
+) *DomainsModule {
+	// Services
+	domainService := service.NewDomainService(domainRepo, linkRepo, net.DefaultResolver, []string{cfg.Domain})
+
+	// Handlers
+	domainHandler := handler.NewDomainHandler(domainService)
+
+	return &DomainsModule{
+		Handler: domainHandler,
+	}
+}
+
+// RegisterRoutes registra las rutas del módulo domains
+func (m *DomainsModule) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	r.Route("/api/domains", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Post("/", m.Handler.AddDomain)
+		r.Get("/", m.Handler.ListDomains)
+		r.Get("/{id}", m.Handler.GetDomain)
+		r.Post("/{id}/verify", m.Handler.VerifyDomain)
+		r.Delete("/{id}", m.Handler.DeleteDomain)
+	})
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"short-go/internal/domains/application/service"
+	"short-go/internal/domains/domain/model"
+	sharedContext "short-go/internal/shared/context"
+	sharedhttp "short-go/internal/shared/http"
+	format "short-go/internal/shared/http/utils"
+	sharedValidation "short-go/internal/shared/validation"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type DomainHandler struct {
+	domainService *service.DomainService
+	validator     *validator.Validate
+}
+
+func NewDomainHandler(domainService *service.DomainService) *DomainHandler {
+	return &DomainHandler{
+		domainService: domainService,
+		validator:     sharedValidation.NewValidator(),
+	}
+}
+
+type AddDomainRequest struct {
+	Host string `json:"host" validate:"required,max=253"`
+}
+
+// VerificationResponse indica el registro DNS que hay que crear
+type VerificationResponse struct {
+	RecordType string `json:"recordType"`
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+}
+
+type DomainResponse struct {
+	ID         string `json:"id"`
+	Host       string `json:"host"`
+	Verified   bool   `json:"verified"`
+	VerifiedAt string `json:"verifiedAt,omitempty"`
+	CreatedAt  string `json:"createdAt"`
+	// Solo mientras el dominio está pendiente
+	Verification *VerificationResponse `json:"verification,omitempty"`
+}
+
+// AddDomain - POST /api/domains
+func (h *DomainHandler) AddDomain(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req AddDomainRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	domain, err := h.domainService.AddDomain(userID, req.Host)
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, toResponse(domain))
+}
+
+// ListDomains - GET /api/domains
+func (h *DomainHandler) ListDomains(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	domains, err := h.domainService.ListDomains(userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener los dominios")
+		return
+	}
+
+	items := make([]DomainResponse, len(domains))
+	for i, domain := range domains {
+		items[i] = toResponse(domain)
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, items)
+}
+
+// GetDomain - GET /api/domains/{id}
+func (h *DomainHandler) GetDomain(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	domain, err := h.domainService.GetDomain(userID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, toResponse(domain))
+}
+
+// VerifyDomain - POST /api/domains/{id}/verify
+func (h *DomainHandler) VerifyDomain(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	domain, err := h.domainService.VerifyDomain(userID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, toResponse(domain))
+}
+
+// DeleteDomain - DELETE /api/domains/{id}
+func (h *DomainHandler) DeleteDomain(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	if err := h.domainService.DeleteDomain(userID, chi.URLParam(r, "id")); err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, map[string]string{"message": "Dominio eliminado"})
+}
+
+// toResponse construye la respuesta pública de un dominio
+func toResponse(domain *model.Domain) DomainResponse {
+	resp := DomainResponse{
+		ID:        domain.ID,
+		Host:      domain.Host,
+		Verified:  domain.IsVerified(),
+		CreatedAt: domain.CreatedAt.Format(time.RFC3339),
+	}
+
+	if domain.IsVerified() {
+		resp.VerifiedAt = domain.VerifiedAt.Format(time.RFC3339)
+	} else {
+		resp.Verification = &VerificationResponse{
+			RecordType: "TXT",
+			Name:       domain.VerificationRecord(),
+			Value:      domain.VerificationValue(),
+		}
+	}
+
+	return resp
+}
+
+// manageErrorResponse traduce errores del servicio a códigos HTTP
+func (h *DomainHandler) manageErrorResponse(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch err {
+	case service.ErrDomainNotFound:
+		status = http.StatusNotFound
+	case service.ErrInvalidDomainHost, service.ErrDomainReserved, service.ErrDomainLimitReached:
+		status = http.StatusBadRequest
+	case service.ErrDomainAlreadyAdded, service.ErrDomainTaken:
+		status = http.StatusConflict
+	case service.ErrVerificationFailed:
+		status = http.StatusUnprocessableEntity
+	case service.ErrDNSLookupFailed:
+		status = http.StatusBadGateway
+	}
+
+	sharedhttp.ErrorResponse(w, status, err.Error())
+}
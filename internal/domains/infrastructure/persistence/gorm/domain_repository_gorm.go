@@ -0,0 +1,105 @@
+package gorm
+
+import (
+	"short-go/internal/domains/domain/model"
+	"short-go/internal/domains/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type DomainRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewDomainRepository(db *gorm.DB) repository.DomainRepository {
+	return &DomainRepositoryGorm{db: db}
+}
+
+func (r *DomainRepositoryGorm) Create(domain *model.Domain) error {
+	return r.db.Create(toModel(domain)).Error
+}
+
+func (r *DomainRepositoryGorm) FindByID(id string) (*model.Domain, error) {
+	var domainModel DomainModel
+	if err := r.db.Where("id = ?", id).First(&domainModel).Error; err != nil {
+		return nil, err
+	}
+	return toDomain(&domainModel), nil
+}
+
+func (r *DomainRepositoryGorm) FindByUserID(userID string) ([]*model.Domain, error) {
+	var domainModels []DomainModel
+	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&domainModels).Error; err != nil {
+		return nil, err
+	}
+
+	domains := make([]*model.Domain, len(domainModels))
+	for i := range domainModels {
+		domains[i] = toDomain(&domainModels[i])
+	}
+	return domains, nil
+}
+
+func (r *DomainRepositoryGorm) FindByUserAndHost(userID string, host string) (*model.Domain, error) {
+	var domainModel DomainModel
+	if err := r.db.Where("user_id = ? AND host = ?", userID, host).First(&domainModel).Error; err != nil {
+		return nil, err
+	}
+	return toDomain(&domainModel), nil
+}
+
+func (r *DomainRepositoryGorm) FindVerifiedByHost(host string) (*model.Domain, error) {
+	var domainModel DomainModel
+	if err := r.db.Where("host = ? AND verified_at IS NOT NULL", host).First(&domainModel).Error; err != nil {
+		return nil, err
+	}
+	return toDomain(&domainModel), nil
+}
+
+func (r *DomainRepositoryGorm) IsVerifiedHost(host string) (bool, error) {
+	var count int64
+	err := r.db.Model(&DomainModel{}).Where("host = ? AND verified_at IS NOT NULL", host).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *DomainRepositoryGorm) CountByUserID(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&DomainModel{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkVerified falla por el índice único si otra cuenta verificó el host antes
+func (r *DomainRepositoryGorm) MarkVerified(id string, at time.Time) error {
+	return r.db.Model(&DomainModel{}).
+		Where("id = ? AND verified_at IS NULL", id).
+		Update("verified_at", at).Error
+}
+
+func (r *DomainRepositoryGorm) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&DomainModel{}).Error
+}
+
+// toModel convierte model.Domain -> DomainModel
+func toModel(domain *model.Domain) *DomainModel {
+	return &DomainModel{
+		ID:                domain.ID,
+		UserID:            domain.UserID,
+		Host:              domain.Host,
+		VerificationToken: domain.VerificationToken,
+		VerifiedAt:        domain.VerifiedAt,
+		CreatedAt:         domain.CreatedAt,
+	}
+}
+
+// toDomain convierte DomainModel -> model.Domain
+func toDomain(domainModel *DomainModel) *model.Domain {
+	return &model.Domain{
+		ID:                domainModel.ID,
+		UserID:            domainModel.UserID,
+		Host:              domainModel.Host,
+		VerificationToken: domainModel.VerificationToken,
+		VerifiedAt:        domainModel.VerifiedAt,
+		CreatedAt:         domainModel.CreatedAt,
+	}
+}
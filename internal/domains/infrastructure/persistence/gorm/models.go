@@ -0,0 +1,18 @@
+package gorm
+
+import "time"
+
+// DomainModel representa la tabla custom_domains. Varias cuentas pueden
+// reclamar el mismo host, pero solo una puede verificarlo
+type DomainModel struct {
+	ID                string `gorm:"primaryKey;type:text"`
+	UserID            string `gorm:"not null;uniqueIndex:idx_custom_domains_user_host"`
+	Host              string `gorm:"size:253;not null;uniqueIndex:idx_custom_domains_user_host;uniqueIndex:idx_custom_domains_verified_host,where:verified_at IS NOT NULL"`
+	VerificationToken string `gorm:"size:64;not null"`
+	VerifiedAt        *time.Time
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+}
+
+func (DomainModel) TableName() string {
+	return "custom_domains"
+}
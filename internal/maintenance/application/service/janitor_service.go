@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"log"
+	authRepo "short-go/internal/auth/domain/repository"
+	shortLinkRepo "short-go/internal/short-links/domain/repository"
+	"time"
+)
+
+// janitorLockKey identifica el advisory lock del janitor en Postgres
+const janitorLockKey int64 = 0x5347_4a41_4e49 // "SGJANI"
+
+// JanitorConfig controla la frecuencia y el alcance de la limpieza
+type JanitorConfig struct {
+	Interval time.Duration
+	// Tiempo que un enlace expirado se conserva antes de retirarlo
+	ExpiredLinkGrace time.Duration
+	// Si es true los enlaces expirados se archivan en vez de eliminarse
+	ArchiveExpiredLinks bool
+	// Tiempo que un enlace eliminado reserva su código antes de purgarlo
+	DeletedCodeQuarantine time.Duration
+}
+
+// JanitorService elimina periódicamente datos vencidos: enlaces expirados,
+// enlaces eliminados cuya cuarentena terminó, sesiones expiradas y códigos
+// de reseteo de contraseña vencidos
+type JanitorService struct {
+	shortLinkRepo shortLinkRepo.ShortLinkRepository
+	sessionRepo   authRepo.SessionRepository
+	userRepo      authRepo.UserRepository
+	locker        Locker
+	config        JanitorConfig
+}
+
+func NewJanitorService(
+	shortLinkRepo shortLinkRepo.ShortLinkRepository,
+	sessionRepo authRepo.SessionRepository,
+	userRepo authRepo.UserRepository,
+	locker Locker,
+	config JanitorConfig,
+) *JanitorService {
+	return &JanitorService{
+		shortLinkRepo: shortLinkRepo,
+		sessionRepo:   sessionRepo,
+		userRepo:      userRepo,
+		locker:        locker,
+		config:        config,
+	}
+}
+
+// Start ejecuta la limpieza en segundo plano cada Interval hasta que ctx se cancele
+func (s *JanitorService) Start(ctx context.Context) {
+	if s.config.Interval <= 0 {
+		log.Println("[janitor] Deshabilitado (JANITOR_INTERVAL=0)")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.config.Interval)
+		defer ticker.Stop()
+
+		s.RunOnce(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("[janitor] Detenido")
+				return
+			case <-ticker.C:
+				s.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+// RunOnce ejecuta una pasada de limpieza si esta réplica obtiene el candado
+func (s *JanitorService) RunOnce(ctx context.Context) {
+	release, acquired, err := s.locker.TryLock(ctx, janitorLockKey)
+	if err != nil {
+		log.Printf("[janitor] Error tomando el candado: %v", err)
+		return
+	}
+	if !acquired {
+		log.Println("[janitor] Otra réplica está ejecutando el mantenimiento, se omite esta pasada")
+		return
+	}
+	defer release()
+
+	s.cleanExpiredLinks()
+	s.cleanDeletedLinks()
+	s.cleanExpiredSessions()
+	s.cleanExpiredResetCodes()
+}
+
+// ---------------- FUNCIONES AUXILIARES  ---------------
+func (s *JanitorService) cleanExpiredLinks() {
+	cutoff := time.Now().Add(-s.config.ExpiredLinkGrace)
+
+	if s.config.ArchiveExpiredLinks {
+		archived, err := s.shortLinkRepo.ArchiveExpired(cutoff)
+		if err != nil {
+			log.Printf("[janitor] Error archivando enlaces expirados: %v", err)
+			return
+		}
+		log.Printf("[janitor] Enlaces expirados archivados: %d", archived)
+		return
+	}
+
+	purged, err := s.shortLinkRepo.PurgeExpired(cutoff)
+	if err != nil {
+		log.Printf("[janitor] Error eliminando enlaces expirados: %v", err)
+		return
+	}
+	log.Printf("[janitor] Enlaces expirados eliminados: %d", purged)
+}
+
+func (s *JanitorService) cleanDeletedLinks() {
+	cutoff := time.Now().Add(-s.config.DeletedCodeQuarantine)
+
+	purged, err := s.shortLinkRepo.PurgeDeleted(cutoff)
+	if err != nil {
+		log.Printf("[janitor] Error purgando enlaces de la papelera: %v", err)
+		return
+	}
+	log.Printf("[janitor] Enlaces de la papelera purgados: %d", purged)
+}
+
+func (s *JanitorService) cleanExpiredSessions() {
+	deleted, err := s.sessionRepo.DeleteExpired()
+	if err != nil {
+		log.Printf("[janitor] Error eliminando sesiones expiradas: %v", err)
+		return
+	}
+	log.Printf("[janitor] Sesiones expiradas eliminadas: %d", deleted)
+}
+
+func (s *JanitorService) cleanExpiredResetCodes() {
+	cleared, err := s.userRepo.ClearExpiredResetTokens()
+	if err != nil {
+		log.Printf("[janitor] Error limpiando códigos de reseteo: %v", err)
+		return
+	}
+	log.Printf("[janitor] Códigos de reseteo vencidos limpiados: %d", cleared)
+}
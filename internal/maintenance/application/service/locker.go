@@ -0,0 +1,11 @@
+package service
+
+import "context"
+
+// Locker otorga un candado distribuido para que una sola réplica
+// ejecute el mantenimiento a la vez
+type Locker interface {
+	// TryLock intenta tomar el candado sin bloquear. Si lo obtiene,
+	// release debe llamarse al terminar
+	TryLock(ctx context.Context, key int64) (release func(), acquired bool, err error)
+}
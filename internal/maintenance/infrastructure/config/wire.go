@@ -0,0 +1,46 @@
+package config
+
+import (
+	"context"
+	"short-go/config"
+	authGormRepo "short-go/internal/auth/infrastructure/persistence/gorm"
+	"short-go/internal/maintenance/application/service"
+	"short-go/internal/maintenance/infrastructure/lock"
+	shortLinkGormRepo "short-go/internal/short-links/infrastructure/persistence/gorm"
+
+	"gorm.io/gorm"
+)
+
+type MaintenanceModule struct {
+	Janitor *service.JanitorService
+}
+
+func NewMaintenanceModule(db *gorm.DB, cfg *config.Config) *MaintenanceModule {
+	// Repositories
+	shortLinkRepo := shortLinkGormRepo.NewShortLinkRepository(db)
+	sessionRepo := authGormRepo.NewSessionRepository(db)
+	userRepo := authGormRepo.NewUserRepository(db)
+
+	// Services
+	janitor := service.NewJanitorService(
+		shortLinkRepo,
+		sessionRepo,
+		userRepo,
+		lock.NewPostgresAdvisoryLocker(db),
+		service.JanitorConfig{
+			Interval:              cfg.JanitorInterval,
+			ExpiredLinkGrace:      cfg.ExpiredLinkGrace,
+			ArchiveExpiredLinks:   cfg.ExpiredLinkAction == "archive",
+			DeletedCodeQuarantine: cfg.DeletedCodeQuarantine,
+		},
+	)
+
+	return &MaintenanceModule{
+		Janitor: janitor,
+	}
+}
+
+// Start inicia las tareas programadas del módulo
+func (m *MaintenanceModule) Start(ctx context.Context) {
+	m.Janitor.Start(ctx)
+}
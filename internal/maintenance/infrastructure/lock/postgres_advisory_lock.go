@@ -0,0 +1,55 @@
+package lock
+
+import (
+	"context"
+	"log"
+	"short-go/internal/maintenance/application/service"
+
+	"gorm.io/gorm"
+)
+
+// PostgresAdvisoryLocker implementa Locker con pg_try_advisory_lock.
+// El candado pertenece a la sesión, por eso se reserva una conexión
+// dedicada del pool mientras se mantiene tomado
+type PostgresAdvisoryLocker struct {
+	db *gorm.DB
+}
+
+var _ service.Locker = (*PostgresAdvisoryLocker)(nil)
+
+func NewPostgresAdvisoryLocker(db *gorm.DB) *PostgresAdvisoryLocker {
+	return &PostgresAdvisoryLocker{db: db}
+}
+
+func (l *PostgresAdvisoryLocker) TryLock(ctx context.Context, key int64) (func(), bool, error) {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return nil, false, err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() {
+		// Si el unlock falla, cerrar la conexión termina la sesión y libera el candado
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key); err != nil {
+			log.Printf("Error liberando advisory lock %d: %v", key, err)
+		}
+		conn.Close()
+	}
+
+	return release, true, nil
+}
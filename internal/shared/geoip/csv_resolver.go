@@ -0,0 +1,151 @@
+package geoip
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ipRange asocia un rango de IPs con su país
+type ipRange struct {
+	start   netip.Addr
+	end     netip.Addr
+	country string
+}
+
+// CSVResolver resuelve países desde una base de datos local en CSV, cargada
+// en memoria y consultada con búsqueda binaria. Acepta dos formatos por fila:
+//
+//	start_ip,end_ip,country   (p. ej. dbip-country-lite.csv)
+//	cidr,country
+type CSVResolver struct {
+	ranges []ipRange
+}
+
+var _ Resolver = (*CSVResolver)(nil)
+
+func NewCSVResolver(path string) (*CSVResolver, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var ranges []ipRange
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("geoip: línea %d: %w", line, err)
+		}
+
+		entry, ok, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("geoip: línea %d: %w", line, err)
+		}
+		if ok {
+			ranges = append(ranges, entry)
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Less(ranges[j].start)
+	})
+
+	return &CSVResolver{ranges: ranges}, nil
+}
+
+func (r *CSVResolver) CountryCode(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return UnknownCountry
+	}
+	addr = addr.Unmap()
+
+	// Primer rango cuyo inicio es mayor a la IP; el candidato es el anterior
+	i := sort.Search(len(r.ranges), func(i int) bool {
+		return addr.Less(r.ranges[i].start)
+	})
+	if i == 0 {
+		return UnknownCountry
+	}
+
+	candidate := r.ranges[i-1]
+	if candidate.start.BitLen() != addr.BitLen() || candidate.end.Less(addr) {
+		return UnknownCountry
+	}
+
+	return candidate.country
+}
+
+// parseRecord interpreta una fila del CSV. Las filas de encabezado o
+// comentarios se omiten (ok = false)
+func parseRecord(record []string) (ipRange, bool, error) {
+	if len(record) == 0 || strings.HasPrefix(strings.TrimSpace(record[0]), "#") {
+		return ipRange{}, false, nil
+	}
+
+	switch {
+	case len(record) >= 3:
+		start, err := netip.ParseAddr(strings.TrimSpace(record[0]))
+		if err != nil {
+			// Encabezado u otra fila no numérica
+			return ipRange{}, false, nil
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(record[1]))
+		if err != nil {
+			return ipRange{}, false, err
+		}
+		return newRange(start, end, record[2])
+	case len(record) == 2:
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(record[0]))
+		if err != nil {
+			return ipRange{}, false, nil
+		}
+		prefix = prefix.Masked()
+		return newRange(prefix.Addr(), lastAddr(prefix), record[1])
+	default:
+		return ipRange{}, false, nil
+	}
+}
+
+func newRange(start, end netip.Addr, country string) (ipRange, bool, error) {
+	start, end = start.Unmap(), end.Unmap()
+	if start.BitLen() != end.BitLen() || end.Less(start) {
+		return ipRange{}, false, fmt.Errorf("rango inválido %s - %s", start, end)
+	}
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 {
+		return ipRange{}, false, fmt.Errorf("código de país inválido %q", country)
+	}
+
+	return ipRange{start: start, end: end, country: country}, true, nil
+}
+
+// lastAddr calcula la última dirección de un prefijo CIDR
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	hostBits := len(bytes)*8 - prefix.Bits()
+	for i := len(bytes) - 1; i >= 0 && hostBits > 0; i-- {
+		if hostBits >= 8 {
+			bytes[i] = 0xff
+			hostBits -= 8
+		} else {
+			bytes[i] |= byte(1<<hostBits - 1)
+			hostBits = 0
+		}
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
@@ -0,0 +1,19 @@
+package geoip
+
+// UnknownCountry es el código que se usa cuando no se puede resolver el país
+const UnknownCountry = "XX"
+
+// Resolver obtiene el país (ISO 3166-1 alpha-2) de una IP de forma síncrona,
+// sin llamadas de red, para poder usarse durante una redirección
+type Resolver interface {
+	CountryCode(ip string) string
+}
+
+// NoopResolver se usa cuando no hay base de datos local configurada
+type NoopResolver struct{}
+
+var _ Resolver = NoopResolver{}
+
+func (NoopResolver) CountryCode(string) string {
+	return UnknownCountry
+}
@@ -0,0 +1,104 @@
+package infrastructure
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP obtiene la IP del visitante desde RemoteAddr, sin puerto. Detrás
+// de un proxy confiable, TrustedProxies.Middleware ya dejó ahí la IP real
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// TrustedProxies son las redes de los proxies (Traefik, balanceadores) cuyos
+// headers X-Forwarded-For y X-Real-IP se aceptan
+type TrustedProxies struct {
+	networks []*net.IPNet
+}
+
+// ParseTrustedProxies acepta rangos CIDR o IPs sueltas
+func ParseTrustedProxies(values []string) (*TrustedProxies, error) {
+	proxies := &TrustedProxies{}
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("proxy confiable inválido: %q", value)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies.networks = append(proxies.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("proxy confiable inválido: %q", value)
+		}
+		proxies.networks = append(proxies.networks, network)
+	}
+	return proxies, nil
+}
+
+// Middleware reemplaza RemoteAddr por la IP del visitante cuando la petición
+// llega desde un proxy confiable; en otro caso los headers se ignoran
+func (p *TrustedProxies) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ip := p.resolve(r); ip != "" {
+			r.RemoteAddr = net.JoinHostPort(ip, "0")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// resolve recorre X-Forwarded-For de derecha a izquierda y devuelve el primer
+// salto que no es un proxy confiable: los valores a su izquierda los pudo
+// escribir el propio visitante. Vacío = conservar RemoteAddr
+func (p *TrustedProxies) resolve(r *http.Request) string {
+	if !p.trusted(ClientIP(r)) {
+		return ""
+	}
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		client := ""
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if net.ParseIP(hop) == nil {
+				break
+			}
+			client = hop
+			if !p.trusted(hop) {
+				break
+			}
+		}
+		return client
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+	return ""
+}
+
+func (p *TrustedProxies) trusted(value string) bool {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return false
+	}
+	for _, network := range p.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
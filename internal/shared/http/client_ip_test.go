@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTrustedProxiesMiddleware(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1", "2001:db8::/32"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		want       string
+	}{
+		{name: "sin proxy", remoteAddr: "203.0.113.7:5000", want: "203.0.113.7"},
+		{name: "headers de un cliente no confiable", remoteAddr: "203.0.113.7:5000", forwarded: []string{"198.51.100.1"}, realIP: "198.51.100.2", want: "203.0.113.7"},
+		{name: "un salto", remoteAddr: "10.0.0.2:5000", forwarded: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "valor falsificado a la izquierda", remoteAddr: "10.0.0.2:5000", forwarded: []string{"6.6.6.6, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "varios proxies confiables", remoteAddr: "10.0.0.2:5000", forwarded: []string{"6.6.6.6, 198.51.100.1, 192.0.2.1, 10.0.0.3"}, want: "198.51.100.1"},
+		{name: "varios headers", remoteAddr: "10.0.0.2:5000", forwarded: []string{"6.6.6.6", "198.51.100.1, 10.0.0.3"}, want: "198.51.100.1"},
+		{name: "todos los saltos confiables", remoteAddr: "10.0.0.2:5000", forwarded: []string{"10.0.0.4, 10.0.0.3"}, want: "10.0.0.4"},
+		{name: "salto inválido a la izquierda", remoteAddr: "10.0.0.2:5000", forwarded: []string{"basura, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "último salto inválido", remoteAddr: "10.0.0.2:5000", forwarded: []string{"198.51.100.1, basura"}, want: "10.0.0.2"},
+		{name: "X-Real-IP sin X-Forwarded-For", remoteAddr: "10.0.0.2:5000", realIP: "198.51.100.1", want: "198.51.100.1"},
+		{name: "X-Real-IP inválido", remoteAddr: "10.0.0.2:5000", realIP: "basura", want: "10.0.0.2"},
+		{name: "X-Forwarded-For tiene prioridad", remoteAddr: "10.0.0.2:5000", forwarded: []string{"198.51.100.1"}, realIP: "6.6.6.6", want: "198.51.100.1"},
+		{name: "IPv6", remoteAddr: "[2001:db8::1]:5000", forwarded: []string{"2001:db8::2, 2a00:1450::1"}, want: "2a00:1450::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			var got string
+			proxies.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ClientIP(r)
+			})).ServeHTTP(httptest.NewRecorder(), r)
+
+			if got != tt.want {
+				t.Errorf("ClientIP = %q, se esperaba %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		wantErr bool
+	}{
+		{name: "vacío", values: nil},
+		{name: "CIDR e IPs", values: []string{"10.0.0.0/8", "192.0.2.1", "::1"}},
+		{name: "IP inválida", values: []string{"10.0.0"}, wantErr: true},
+		{name: "CIDR inválido", values: []string{"10.0.0.0/33"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTrustedProxies(tt.values); (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, se esperaba error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package infrastructure
+
+// ManagementTokenHeader es el header con el que los creadores anónimos
+// presentan el token de gestión de su enlace
+const ManagementTokenHeader = "X-Management-Token"
@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+)
+
+// ParsePagination lee los parámetros 'page' y 'pageSize' de la consulta.
+// Los ausentes quedan en 0 para que el servicio aplique sus valores por defecto
+func ParsePagination(r *http.Request) (page, pageSize int, err error) {
+	query := r.URL.Query()
+
+	if raw := query.Get("page"); raw != "" {
+		page, err = strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return 0, 0, errors.New("Parámetro 'page' inválido")
+		}
+	}
+
+	if raw := query.Get("pageSize"); raw != "" {
+		pageSize, err = strconv.Atoi(raw)
+		if err != nil || pageSize < 1 {
+			return 0, 0, errors.New("Parámetro 'pageSize' inválido")
+		}
+	}
+
+	return page, pageSize, nil
+}
+
+// PageStyle es el estilo común de las páginas HTML que ven los visitantes:
+// una tarjeta centrada. Cada página puede agregar sus propias reglas
+const PageStyle = `body{font-family:system-ui,sans-serif;background:#f5f5f7;color:#1d1d1f;display:flex;align-items:center;justify-content:center;min-height:100vh;margin:0}
+main{background:#fff;border-radius:12px;padding:2rem;max-width:28rem;box-shadow:0 2px 12px rgba(0,0,0,.08);text-align:center}
+h1{font-size:1.4rem;margin-top:0}
+p{line-height:1.5;color:#444}
+`
+
+// messagePageTemplate es la página HTML mínima con un título y un mensaje
+var messagePageTemplate = template.Must(template.New("message").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}} · ShortGo</title>
+<style>
+` + PageStyle + `</style>
+</head>
+<body>
+<main>
+<h1>{{.Title}}</h1>
+<p>{{.Message}}</p>
+</main>
+</body>
+</html>`))
+
+type messagePage struct {
+	Title   string
+	Message string
+}
+
+// RenderMessagePage responde con una página HTML informativa
+func RenderMessagePage(w http.ResponseWriter, status int, title, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	messagePageTemplate.Execute(w, messagePage{Title: title, Message: message})
+}
@@ -0,0 +1,29 @@
+package infrastructure
+
+import (
+	"short-go/config"
+	"strings"
+)
+
+// BaseURL es la URL del dominio compartido. En local incluye el puerto
+func BaseURL(cfg *config.Config) string {
+	if cfg.Port != "" && cfg.Domain == "http://localhost" {
+		return cfg.Domain + ":" + cfg.Port
+	}
+	return cfg.Domain
+}
+
+// ShortURLBase es la base de la URL corta de un enlace: su dominio
+// personalizado, con el mismo esquema que el dominio compartido, o el
+// dominio compartido si domain está vacío
+func ShortURLBase(cfg *config.Config, domain string) string {
+	if domain == "" {
+		return BaseURL(cfg)
+	}
+
+	scheme := "https"
+	if strings.HasPrefix(cfg.Domain, "http://") {
+		scheme = "http"
+	}
+	return scheme + "://" + domain
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -16,7 +17,15 @@ func FormatValidationError(err error) string {
 			case "email":
 				return "Formato de email inválido"
 			case "min":
+				if e.Kind() == reflect.Slice {
+					return fmt.Sprintf("El campo '%s' debe tener al menos %s elementos", field, e.Param())
+				}
 				return fmt.Sprintf("El campo '%s' debe tener al menos %s caracteres", field, e.Param())
+			case "max":
+				if e.Kind() == reflect.Slice {
+					return fmt.Sprintf("El campo '%s' admite como máximo %s elementos", field, e.Param())
+				}
+				return fmt.Sprintf("El campo '%s' debe tener como máximo %s caracteres", field, e.Param())
 			}
 		}
 	}
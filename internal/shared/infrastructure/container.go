@@ -1,6 +1,9 @@
 package infrastructure
 
 import (
+	"context"
+	"log"
+
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
 	"short-go/config"
@@ -9,43 +12,81 @@ import (
 	analyticsGorm "short-go/internal/analytics/infrastructure/persistence/gorm"
 	authConfig "short-go/internal/auth/infrastructure/config"
 	gormRepo "short-go/internal/auth/infrastructure/persistence/gorm"
+	bioPagesConfig "short-go/internal/bio-pages/infrastructure/config"
+	domainsConfig "short-go/internal/domains/infrastructure/config"
+	domainsGorm "short-go/internal/domains/infrastructure/persistence/gorm"
+	maintenanceConfig "short-go/internal/maintenance/infrastructure/config"
 	qrConfig "short-go/internal/qr/infrastructure/config"
+	"short-go/internal/shared/geoip"
 	"short-go/internal/shared/infrastructure/middleware"
 	shortenerConfig "short-go/internal/short-links/infrastructure/config"
 	shortLinkGormRepo "short-go/internal/short-links/infrastructure/persistence/gorm"
 )
 
 type Container struct {
-	AuthModule      *authConfig.AuthModule
-	AuthMiddleware  *middleware.AuthMiddleware
-	ShortenerModule *shortenerConfig.ShortenerModule
-	QRModule        *qrConfig.QRModule
-	AnalyticsModule *analyticsConfig.AnalyticsModule
+	AuthModule        *authConfig.AuthModule
+	AuthMiddleware    *middleware.AuthMiddleware
+	ShortenerModule   *shortenerConfig.ShortenerModule
+	QRModule          *qrConfig.QRModule
+	DomainsModule     *domainsConfig.DomainsModule
+	BioPagesModule    *bioPagesConfig.BioPagesModule
+	AnalyticsModule   *analyticsConfig.AnalyticsModule
+	MaintenanceModule *maintenanceConfig.MaintenanceModule
 }
 
 func NewContainer(db *gorm.DB, cfg *config.Config) *Container {
 	sessionRepo := gormRepo.NewSessionRepository(db)
+	userRepo := gormRepo.NewUserRepository(db)
 
 	// Repos
 	linkRepo := shortLinkGormRepo.NewShortLinkRepository(db)
 	clickRepo := analyticsGorm.NewClickRepository(db)
+	domainRepo := domainsGorm.NewDomainRepository(db)
+
+	// GeoIP local compartido por redirecciones y analíticas
+	geoResolver := newGeoResolver(cfg.GeoIPDatabasePath)
 
 	// Services
-	analyticsService := analyticsService.NewAnalyticsService(clickRepo, linkRepo)
+	analyticsService := analyticsService.NewAnalyticsService(clickRepo, linkRepo, geoResolver)
 
 	return &Container{
-		AuthModule:      authConfig.NewAuthModule(db, cfg.JWTSecret, cfg.EmailsAPIKey, cfg.SenderEmail),
-		AuthMiddleware:  middleware.NewAuthMiddleware(cfg.JWTSecret, sessionRepo),
-		ShortenerModule: shortenerConfig.NewShortenerModule(db, cfg, analyticsService),
-		QRModule:        qrConfig.NewQRModule(cfg),
-		AnalyticsModule: analyticsConfig.NewAnalyticsModule(db, linkRepo),
+		AuthModule:        authConfig.NewAuthModule(db, cfg.JWTSecret, cfg.EmailsAPIKey, cfg.SenderEmail, cfg.AdminEmails),
+		AuthMiddleware:    middleware.NewAuthMiddleware(cfg.JWTSecret, sessionRepo, userRepo),
+		ShortenerModule:   shortenerConfig.NewShortenerModule(db, cfg, analyticsService, geoResolver, domainRepo),
+		QRModule:          qrConfig.NewQRModule(cfg),
+		DomainsModule:     domainsConfig.NewDomainsModule(cfg, domainRepo, linkRepo),
+		BioPagesModule:    bioPagesConfig.NewBioPagesModule(db, cfg, linkRepo, geoResolver),
+		AnalyticsModule:   analyticsConfig.NewAnalyticsModule(db, linkRepo, geoResolver),
+		MaintenanceModule: maintenanceConfig.NewMaintenanceModule(db, cfg),
 	}
 }
 
+// newGeoResolver carga la base GeoIP local; sin ella los países quedan como desconocidos
+func newGeoResolver(path string) geoip.Resolver {
+	if path == "" {
+		return geoip.NoopResolver{}
+	}
+
+	resolver, err := geoip.NewCSVResolver(path)
+	if err != nil {
+		log.Printf("Warning: no se pudo cargar la base GeoIP (%s): %v", path, err)
+		return geoip.NoopResolver{}
+	}
+
+	return resolver
+}
+
 // RegisterRoutes registra las rutas de todos los módulos
 func (c *Container) RegisterRoutes(r chi.Router) {
 	c.AuthModule.RegisterRoutes(r, c.AuthMiddleware)
 	c.ShortenerModule.RegisterRoutes(r, c.AuthMiddleware)
 	c.QRModule.RegisterRoutes(r)
+	c.DomainsModule.RegisterRoutes(r, c.AuthMiddleware)
+	c.BioPagesModule.RegisterRoutes(r, c.AuthMiddleware)
 	c.AnalyticsModule.RegisterRoutes(r, c.AuthMiddleware)
-}
\ No newline at end of file
+}
+
+// StartBackgroundJobs inicia las tareas programadas hasta que ctx se cancele
+func (c *Container) StartBackgroundJobs(ctx context.Context) {
+	c.MaintenanceModule.Start(ctx)
+}
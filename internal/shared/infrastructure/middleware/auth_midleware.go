@@ -14,12 +14,14 @@ import (
 type AuthMiddleware struct {
 	jwtSecret   string
 	sessionRepo repository.SessionRepository
+	userRepo    repository.UserRepository
 }
 
-func NewAuthMiddleware(jwtSecret string, sessionRepo repository.SessionRepository) *AuthMiddleware {
+func NewAuthMiddleware(jwtSecret string, sessionRepo repository.SessionRepository, userRepo repository.UserRepository) *AuthMiddleware {
 	return &AuthMiddleware{
 		jwtSecret:   jwtSecret,
 		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
 	}
 }
 
@@ -66,6 +68,20 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	})
 }
 
+// RequireAdmin valida el JWT como RequireAuth y además exige el rol admin.
+// El rol se consulta en cada petición para que quitarlo tenga efecto inmediato
+func (m *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
+	return m.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := m.userRepo.FindByID(sharedContext.GetUserID(r.Context()))
+		if err != nil || !user.IsAdmin() {
+			sharedhttp.ErrorResponse(w, http.StatusForbidden, "Se requieren permisos de administrador")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}))
+}
+
 // OptionalAuth intenta validar el JWT si está presente y extrae el userId
 // Si existe y el token es inválido, retorna un error 401
 func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
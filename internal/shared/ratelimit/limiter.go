@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter cuenta intentos por clave en ventanas fijas. Las cuentas viven en
+// memoria: cada réplica limita por su cuenta
+type Limiter struct {
+	limit  int
+	window time.Duration
+
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+}
+
+type counter struct {
+	count   int
+	resetAt time.Time
+}
+
+// NewLimiter permite hasta limit intentos por clave en cada ventana
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:    limit,
+		window:   window,
+		counters: make(map[string]*counter),
+	}
+}
+
+// Allow registra un intento de key e indica si todavía está dentro del límite
+func (l *Limiter) Allow(key string) bool {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	c, ok := l.counters[key]
+	if !ok || !now.Before(c.resetAt) {
+		c = &counter{resetAt: now.Add(l.window)}
+		l.counters[key] = c
+	}
+
+	if c.count >= l.limit {
+		return false
+	}
+	c.count++
+	return true
+}
+
+// sweep descarta las ventanas vencidas, como máximo una vez por ventana
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	l.lastSweep = now
+
+	for key, c := range l.counters {
+		if !now.Before(c.resetAt) {
+			delete(l.counters, key)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package timeutil
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration extiende time.ParseDuration con el sufijo "d" (días),
+// por ejemplo "30d" o "7d". "0" representa una duración nula
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, &time.ParseError{Layout: "<n>d", Value: value, Message: ": duración en días inválida"}
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,80 @@
+package urlutil
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var ErrInvalidHost = errors.New("nombre de dominio inválido")
+
+const (
+	maxLabelLength = 63
+	maxHostLength  = 253
+)
+
+// HostToASCII convierte un dominio internacionalizado (IDN) a su forma ASCII
+// con el perfil de búsqueda de IDNA (UTS #46): mapea mayúsculas y variantes
+// de ancho completo, codifica en Punycode y rechaza etiquetas "xn--"
+// inválidas o que no cumplen las reglas bidi y contextuales
+func HostToASCII(host string) (string, error) {
+	host = strings.TrimSuffix(host, ".")
+	if host == "" {
+		return "", ErrInvalidHost
+	}
+
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil || len(ascii) > maxHostLength {
+		return "", ErrInvalidHost
+	}
+
+	for _, label := range strings.Split(ascii, ".") {
+		if !validLabel(label) {
+			return "", ErrInvalidHost
+		}
+	}
+
+	return ascii, nil
+}
+
+// NormalizeHost deja un dominio ingresado por el usuario o recibido en el
+// header Host en su forma canónica: sin esquema, ruta ni puerto, en
+// minúsculas y en Punycode. Acepta "ejemplo.com", "ejemplo.com:8080" o
+// "https://ejemplo.com/ruta"
+func NormalizeHost(raw string) (string, error) {
+	host := strings.TrimSpace(raw)
+	if strings.Contains(host, "://") {
+		parsed, err := url.Parse(host)
+		if err != nil {
+			return "", ErrInvalidHost
+		}
+		host = parsed.Host
+	}
+
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	return HostToASCII(host)
+}
+
+// validLabel comprueba una etiqueta ya en ASCII: letras, dígitos y guiones,
+// sin guion al inicio ni al final
+func validLabel(label string) bool {
+	if label == "" || len(label) > maxLabelLength {
+		return false
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"short-go/internal/short-links/domain/model"
+	"short-go/internal/short-links/domain/repository"
+)
+
+var (
+	ErrBulkEmpty       = errors.New("no hay enlaces para crear")
+	ErrBulkTooManyRows = errors.New("se pueden crear hasta 5000 enlaces por solicitud")
+	ErrBulkChunkFailed = errors.New("no se pudo guardar el enlace, intenta nuevamente")
+)
+
+const (
+	// MaxBulkRows es el límite de filas por creación masiva
+	MaxBulkRows = 5000
+	// Filas insertadas por transacción: un error de base de datos solo
+	// revierte su bloque y el resto de la importación continúa
+	bulkChunkSize = 200
+)
+
+// BulkLinkInput es una fila de una creación masiva. Row es su posición en
+// el archivo o arreglo original (desde 1)
+type BulkLinkInput struct {
+	Row        int
+	URL        string
+	Alias      string
+	Expiration ExpirationInput
+	Title      string
+	Tags       []string
+	Folder     string
+}
+
+// BulkRowResult es el resultado de una fila: el enlace creado o el error
+type BulkRowResult struct {
+	Row       int
+	ShortLink *model.ShortLink
+	Err       error
+}
+
+// BulkResult resume una creación masiva, en el orden de las filas recibidas
+type BulkResult struct {
+	Results []BulkRowResult
+	Created int
+	Failed  int
+}
+
+// CreateShortLinksBulk crea los enlaces de un usuario reutilizando las mismas
+// validaciones que CreateShortLink. Cada fila se resuelve por separado: las
+// inválidas se reportan y no impiden crear las demás
+func (s *ShortLinkService) CreateShortLinksBulk(userID string, rows []BulkLinkInput) (*BulkResult, error) {
+	if len(rows) == 0 {
+		return nil, ErrBulkEmpty
+	}
+	if len(rows) > MaxBulkRows {
+		return nil, ErrBulkTooManyRows
+	}
+
+	results := make([]BulkRowResult, len(rows))
+	prepared := make([]int, 0, len(rows))
+
+	for i, row := range rows {
+		results[i].Row = row.Row
+		shortLink, err := s.buildShortLink(CreateShortLinkInput{
+			OriginalURL: row.URL,
+			Alias:       row.Alias,
+			UserID:      &userID,
+			Expiration:  row.Expiration,
+			Title:       row.Title,
+			Tags:        row.Tags,
+			Folder:      row.Folder,
+		})
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].ShortLink = shortLink
+		prepared = append(prepared, i)
+	}
+
+	for start := 0; start < len(prepared); start += bulkChunkSize {
+		chunk := prepared[start:min(start+bulkChunkSize, len(prepared))]
+		s.insertBulkChunk(rows, results, chunk)
+	}
+
+	summary := &BulkResult{Results: results}
+	for _, result := range results {
+		if result.Err != nil {
+			summary.Failed++
+		} else {
+			summary.Created++
+		}
+	}
+	return summary, nil
+}
+
+// insertBulkChunk inserta un bloque de filas en una transacción. Los errores
+// de validación (alias ocupado, sin códigos disponibles) quedan en la fila;
+// un error de base de datos revierte el bloque completo
+func (s *ShortLinkService) insertBulkChunk(rows []BulkLinkInput, results []BulkRowResult, chunk []int) {
+	err := s.shortLinkRepo.Transaction(func(repo repository.ShortLinkRepository) error {
+		for _, i := range chunk {
+			shortLink := results[i].ShortLink
+			err := s.insertWithUniqueCode(repo, shortLink, rows[i].Alias)
+			switch {
+			case err == nil:
+				revision := newRevision(shortLink, creatorAccess(shortLink), model.RevisionCreate)
+				if err := repo.CreateRevision(revision); err != nil {
+					return err
+				}
+			case errors.Is(err, ErrAliasTaken), errors.Is(err, ErrCodeSpaceExhausted):
+				results[i].ShortLink = nil
+				results[i].Err = err
+			default:
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return
+	}
+
+	log.Printf("Error en la creación masiva, se revierte un bloque de %d enlaces: %v", len(chunk), err)
+	for _, i := range chunk {
+		if results[i].Err == nil {
+			results[i].ShortLink = nil
+			results[i].Err = ErrBulkChunkFailed
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const codeCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// CodeGenerator abstrae la generación de códigos cortos y tokens aleatorios
+type CodeGenerator interface {
+	Generate(length int) (string, error)
+}
+
+// RandomCodeGenerator genera códigos alfanuméricos usando crypto/rand
+type RandomCodeGenerator struct{}
+
+var _ CodeGenerator = (*RandomCodeGenerator)(nil)
+
+func NewRandomCodeGenerator() *RandomCodeGenerator {
+	return &RandomCodeGenerator{}
+}
+
+func (g *RandomCodeGenerator) Generate(length int) (string, error) {
+	b := make([]byte, length)
+	max := big.NewInt(int64(len(codeCharset)))
+	for i := range b {
+		// Usa crypto/rand para obtener un número seguro
+		num, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = codeCharset[num.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"short-go/internal/shared/urlutil"
+	"short-go/internal/short-links/domain/model"
+)
+
+var (
+	ErrDomainRequiresAuth = errors.New("solo los enlaces de una cuenta pueden usar un dominio personalizado")
+	ErrDomainNotVerified  = errors.New("el dominio no está verificado en tu cuenta")
+)
+
+// resolveLinkDomain valida que el dominio pedido para un enlace esté
+// verificado por su dueño y lo devuelve normalizado. "" deja el enlace en
+// el dominio compartido
+func (s *ShortLinkService) resolveLinkDomain(rawHost string, userID *string) (string, error) {
+	if rawHost == "" {
+		return "", nil
+	}
+	if userID == nil {
+		return "", ErrDomainRequiresAuth
+	}
+
+	host, err := urlutil.NormalizeHost(rawHost)
+	if err != nil {
+		return "", ErrDomainNotVerified
+	}
+
+	domain, err := s.domainRepo.FindVerifiedByHost(host)
+	if err != nil || domain.UserID != *userID {
+		return "", ErrDomainNotVerified
+	}
+
+	return host, nil
+}
+
+// servedOn indica si el enlace responde en host. Un dominio personalizado
+// solo sirve los enlaces que su dueño publicó en él; el dominio compartido
+// (o un host desconocido) sirve todos, así que los enlaces ya difundidos
+// siguen funcionando después de pasarlos a un dominio propio.
+//
+// El par (host, código) solo decide dónde responde un enlace: los códigos
+// siguen siendo únicos entre todos los dominios porque el código identifica
+// al enlace en la API de gestión, las estadísticas, el QR, los clicks, las
+// revisiones y los reportes. Códigos únicos por dominio exigirían llevar el
+// dominio en todas esas rutas y tablas
+func (s *ShortLinkService) servedOn(shortLink *model.ShortLink, host string) bool {
+	if host == "" {
+		return true
+	}
+
+	domain, err := s.domainRepo.FindVerifiedByHost(host)
+	if err != nil {
+		return true
+	}
+
+	return shortLink.Domain == domain.Host &&
+		shortLink.UserID != nil && *shortLink.UserID == domain.UserID
+}
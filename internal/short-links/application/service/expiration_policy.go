@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrExpirationTooFar       = errors.New("la fecha de expiración supera el máximo permitido")
+	ErrNeverExpiresNotAllowed = errors.New("este enlace no puede configurarse sin expiración")
+	ErrExpirationConflict     = errors.New("usa solo una opción: expiresAt, expiresIn o neverExpires")
+)
+
+// ExpirationPolicy define la expiración por defecto y máxima según el tipo
+// de creador. Un máximo en 0 significa sin límite (y permite "nunca expira")
+type ExpirationPolicy struct {
+	AnonymousDefault time.Duration
+	AnonymousMax     time.Duration
+	UserDefault      time.Duration
+	UserMax          time.Duration
+}
+
+// ExpirationInput es la expiración solicitada para un enlace: una fecha
+// absoluta, una duración relativa o "nunca expira". Vacío = valor por defecto
+type ExpirationInput struct {
+	ExpiresAt    *time.Time
+	ExpiresIn    time.Duration
+	NeverExpires bool
+}
+
+func (in ExpirationInput) isEmpty() bool {
+	return in.ExpiresAt == nil && in.ExpiresIn == 0 && !in.NeverExpires
+}
+
+// resolve calcula la fecha de expiración final a partir de la solicitud.
+// Retorna nil cuando el enlace no expira
+func (p ExpirationPolicy) resolve(in ExpirationInput, authenticated bool, now time.Time) (*time.Time, error) {
+	defaultTTL, maxTTL := p.AnonymousDefault, p.AnonymousMax
+	if authenticated {
+		defaultTTL, maxTTL = p.UserDefault, p.UserMax
+	}
+
+	options := 0
+	if in.ExpiresAt != nil {
+		options++
+	}
+	if in.ExpiresIn != 0 {
+		options++
+	}
+	if in.NeverExpires {
+		options++
+	}
+	if options > 1 {
+		return nil, ErrExpirationConflict
+	}
+
+	var expiresAt time.Time
+	switch {
+	case in.NeverExpires:
+		if !authenticated || maxTTL > 0 {
+			return nil, ErrNeverExpiresNotAllowed
+		}
+		return nil, nil
+	case in.ExpiresAt != nil:
+		expiresAt = *in.ExpiresAt
+	case in.ExpiresIn != 0:
+		expiresAt = now.Add(in.ExpiresIn)
+	default:
+		// Por defecto en 0 para usuarios: sus enlaces no expiran
+		if defaultTTL <= 0 {
+			return nil, nil
+		}
+		expiresAt = now.Add(defaultTTL)
+	}
+
+	if !expiresAt.After(now) {
+		return nil, ErrInvalidExpiration
+	}
+
+	if maxTTL > 0 && expiresAt.After(now.Add(maxTTL)) {
+		return nil, ErrExpirationTooFar
+	}
+
+	return &expiresAt, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"short-go/internal/short-links/domain/model"
+	"short-go/internal/short-links/domain/repository"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidReportReason      = errors.New("motivo de reporte inválido: usa phishing, malware, spam, inappropriate u other")
+	ErrReportDetailsTooLong     = errors.New("los detalles del reporte no pueden superar los 1000 caracteres")
+	ErrInvalidReportStatus      = errors.New("estado de reporte inválido: usa open, resolved o dismissed")
+	ErrModerationReasonRequired = errors.New("indica el motivo de la acción de moderación")
+)
+
+const maxReportDetailsLength = 1000
+
+var validReportReasons = map[string]bool{
+	model.ReportReasonPhishing:      true,
+	model.ReportReasonMalware:       true,
+	model.ReportReasonSpam:          true,
+	model.ReportReasonInappropriate: true,
+	model.ReportReasonOther:         true,
+}
+
+// moderationActions es la acción del historial para cada estado de moderación
+var moderationActions = map[string]string{
+	model.ModerationDisabled: model.RevisionDisable,
+	model.ModerationBanned:   model.RevisionBan,
+	model.ModerationActive:   model.RevisionReinstate,
+}
+
+var validReportStatuses = map[string]bool{
+	model.ReportStatusOpen:      true,
+	model.ReportStatusResolved:  true,
+	model.ReportStatusDismissed: true,
+}
+
+type ModerationService struct {
+	shortLinkRepo repository.ShortLinkRepository
+	reportRepo    repository.ReportRepository
+}
+
+type ReportShortLinkInput struct {
+	Code           string
+	Reason         string
+	Details        string
+	ReporterIP     string
+	ReporterUserID *string
+}
+
+func NewModerationService(shortLinkRepo repository.ShortLinkRepository, reportRepo repository.ReportRepository) *ModerationService {
+	return &ModerationService{
+		shortLinkRepo: shortLinkRepo,
+		reportRepo:    reportRepo,
+	}
+}
+
+// ReportShortLink registra un reporte de abuso. Un segundo reporte abierto
+// desde la misma IP para el mismo enlace se ignora sin error
+func (s *ModerationService) ReportShortLink(input ReportShortLinkInput) error {
+	reason := strings.ToLower(strings.TrimSpace(input.Reason))
+	if !validReportReasons[reason] {
+		return ErrInvalidReportReason
+	}
+
+	details := strings.TrimSpace(input.Details)
+	if len([]rune(details)) > maxReportDetailsLength {
+		return ErrReportDetailsTooLong
+	}
+
+	if _, err := s.shortLinkRepo.FindByCode(input.Code); err != nil {
+		return ErrShortLinkNotFound
+	}
+
+	if input.ReporterIP != "" {
+		duplicated, err := s.reportRepo.HasOpenReport(input.Code, input.ReporterIP)
+		if err != nil {
+			return err
+		}
+		if duplicated {
+			return nil
+		}
+	}
+
+	return s.reportRepo.Create(&model.Report{
+		LinkCode:       input.Code,
+		Reason:         reason,
+		Details:        details,
+		ReporterIP:     input.ReporterIP,
+		ReporterUserID: input.ReporterUserID,
+		Status:         model.ReportStatusOpen,
+		CreatedAt:      time.Now(),
+	})
+}
+
+// ListReports - Lista paginada de reportes, opcionalmente filtrada por estado
+func (s *ModerationService) ListReports(opts model.ReportListOptions) (*model.ReportPage, error) {
+	if opts.Status != "" && !validReportStatuses[opts.Status] {
+		return nil, ErrInvalidReportStatus
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.PageSize < 1 {
+		opts.PageSize = defaultPageSize
+	}
+	if opts.PageSize > maxPageSize {
+		opts.PageSize = maxPageSize
+	}
+
+	reports, total, err := s.reportRepo.List(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ReportPage{
+		Items:    reports,
+		Total:    total,
+		Page:     opts.Page,
+		PageSize: opts.PageSize,
+	}, nil
+}
+
+// DisableShortLink suspende el enlace; el dueño conserva el control
+func (s *ModerationService) DisableShortLink(code, reason, adminID string) (*model.ShortLink, error) {
+	return s.moderate(code, model.ModerationDisabled, reason, adminID, model.ReportStatusResolved)
+}
+
+// BanShortLink bloquea el enlace; el dueño ya no puede editarlo ni eliminarlo
+func (s *ModerationService) BanShortLink(code, reason, adminID string) (*model.ShortLink, error) {
+	return s.moderate(code, model.ModerationBanned, reason, adminID, model.ReportStatusResolved)
+}
+
+// RestoreShortLink reactiva el enlace y descarta sus reportes abiertos
+func (s *ModerationService) RestoreShortLink(code, reason, adminID string) (*model.ShortLink, error) {
+	return s.moderate(code, model.ModerationActive, reason, adminID, model.ReportStatusDismissed)
+}
+
+// moderate guarda el nuevo estado con su revisión, hecha por el admin, y
+// cierra los reportes abiertos del enlace
+func (s *ModerationService) moderate(code, status, reason, adminID, reportStatus string) (*model.ShortLink, error) {
+	reason = strings.TrimSpace(reason)
+	if status != model.ModerationActive && reason == "" {
+		return nil, ErrModerationReasonRequired
+	}
+
+	shortLink, err := s.shortLinkRepo.FindByCode(code)
+	if err != nil {
+		return nil, ErrShortLinkNotFound
+	}
+
+	now := time.Now()
+	revision := newRevision(shortLink, LinkAccess{}, moderationActions[status])
+	revision.ActorType = model.RevisionActorAdmin
+	revision.ActorUserID = &adminID
+
+	err = recordStateChange(s.shortLinkRepo, shortLink, revision, func(repo repository.ShortLinkRepository) (bool, error) {
+		return repo.SetModeration(code, model.Moderation{
+			Status:      status,
+			Reason:      reason,
+			ModeratedBy: adminID,
+			ModeratedAt: now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.reportRepo.CloseOpenReports(code, reportStatus, adminID, now); err != nil {
+		return nil, err
+	}
+
+	shortLink, err = s.shortLinkRepo.FindByCode(code)
+	if err != nil {
+		return nil, ErrShortLinkNotFound
+	}
+	return shortLink, nil
+}
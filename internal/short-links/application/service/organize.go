@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"short-go/internal/shared/urlutil"
+	"short-go/internal/short-links/domain/model"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidTitle           = errors.New("el título no puede superar los 255 caracteres")
+	ErrInvalidFolder          = errors.New("la carpeta debe tener hasta 64 caracteres")
+	ErrInvalidDestinationHost = errors.New("dominio de destino del filtro inválido")
+	ErrInvalidDomainFilter    = errors.New("dominio personalizado del filtro inválido")
+)
+
+const (
+	maxTitleLength  = 255
+	maxFolderLength = 64
+	// Palabras máximas de una búsqueda
+	maxSearchTerms = 8
+)
+
+// ListUserTags - Etiquetas usadas en los enlaces del usuario, con su conteo
+func (s *ShortLinkService) ListUserTags(userID string) ([]model.TagCount, error) {
+	return s.shortLinkRepo.ListTags(userID)
+}
+
+// ListUserFolders - Carpetas del usuario, con su cantidad de enlaces
+func (s *ShortLinkService) ListUserFolders(userID string) ([]model.FolderCount, error) {
+	return s.shortLinkRepo.ListFolders(userID)
+}
+
+func normalizeTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if len([]rune(title)) > maxTitleLength {
+		return "", ErrInvalidTitle
+	}
+	return title, nil
+}
+
+// normalizeFolder quita los espacios sobrantes; "" deja el enlace sin carpeta
+func normalizeFolder(folder string) (string, error) {
+	folder = strings.Join(strings.Fields(folder), " ")
+	if len([]rune(folder)) > maxFolderLength {
+		return "", ErrInvalidFolder
+	}
+	return folder, nil
+}
+
+// normalizeListFilter normaliza el filtro del listado igual que se guardan
+// los datos: etiquetas en minúsculas, dominio en Punycode y la búsqueda como
+// consulta de prefijos para to_tsquery
+func normalizeListFilter(filter model.ListFilter) (model.ListFilter, error) {
+	tags, err := normalizeTags(filter.Tags)
+	if err != nil {
+		return filter, err
+	}
+	filter.Tags = tags
+
+	if filter.Folder, err = normalizeFolder(filter.Folder); err != nil {
+		return filter, err
+	}
+
+	if filter.DestinationHost != "" {
+		host, err := urlutil.HostToASCII(strings.TrimPrefix(strings.TrimSpace(filter.DestinationHost), "www."))
+		if err != nil {
+			return filter, ErrInvalidDestinationHost
+		}
+		filter.DestinationHost = host
+	}
+
+	if filter.Domain != "" {
+		host, err := urlutil.NormalizeHost(filter.Domain)
+		if err != nil {
+			return filter, ErrInvalidDomainFilter
+		}
+		filter.Domain = host
+	}
+
+	filter.Query = searchQuery(filter.Query)
+	return filter, nil
+}
+
+// searchQuery convierte el texto del usuario en una consulta tsquery segura:
+// cada palabra alfanumérica se busca como prefijo y todas deben coincidir
+func searchQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchTerms {
+		words = words[:maxSearchTerms]
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"short-go/internal/short-links/domain/model"
+	"strings"
+)
+
+var (
+	ErrInvalidQueryPriority = errors.New("queryPriority debe ser 'destination' o 'incoming'")
+	ErrInvalidUTM           = errors.New("los parámetros UTM no pueden superar los 255 caracteres")
+)
+
+const maxUTMLength = 255
+
+// ApplyPassthrough completa el destino con la ruta adicional, los UTM guardados
+// en el enlace y los parámetros de la visita. Los UTM del enlace reemplazan a
+// los del destino y, ante conflicto con la visita, cuentan como parte del
+// destino. Si el destino no se puede interpretar como URL se devuelve sin cambios
+func (s *ShortLinkService) ApplyPassthrough(shortLink *model.ShortLink, destination string, query url.Values, extraPath string) string {
+	if !shortLink.ForwardPath {
+		extraPath = ""
+	}
+
+	utm := shortLink.UTM.Values()
+	forwardQuery := shortLink.ForwardQuery && len(query) > 0
+	if extraPath == "" && !forwardQuery && len(utm) == 0 {
+		return destination
+	}
+
+	target, err := url.Parse(destination)
+	if err != nil {
+		return destination
+	}
+
+	if extraPath != "" {
+		joinPath(target, extraPath)
+	}
+
+	if len(utm) > 0 || forwardQuery {
+		merged := target.Query()
+		for key, values := range utm {
+			merged[key] = values
+		}
+		if forwardQuery {
+			merged = mergeQuery(merged, query, shortLink.QueryPriority)
+		}
+		target.RawQuery = merged.Encode()
+	}
+
+	return target.String()
+}
+
+// joinPath agrega la ruta adicional al final de la ruta del destino. La ruta se
+// limpia como absoluta para que ".." no pueda salir de la ruta base del destino
+func joinPath(target *url.URL, extraPath string) {
+	cleaned := path.Clean("/" + extraPath)
+	if cleaned == "/" {
+		return
+	}
+
+	base := strings.TrimSuffix(target.Path, "/")
+	target.Path = base + cleaned
+	target.RawPath = ""
+}
+
+// mergeQuery combina los parámetros del destino con los de la visita. Ante un
+// parámetro repetido gana el lado indicado por priority
+func mergeQuery(destination, incoming url.Values, priority string) url.Values {
+	merged := url.Values{}
+	for key, values := range destination {
+		merged[key] = values
+	}
+
+	for key, values := range incoming {
+		if _, exists := merged[key]; exists && priority != model.QueryPriorityIncoming {
+			continue
+		}
+		merged[key] = values
+	}
+
+	return merged
+}
+
+// normalizeQueryPriority valida la prioridad de parámetros; vacío usa la del destino
+func normalizeQueryPriority(priority string) (string, error) {
+	switch priority {
+	case "":
+		return model.QueryPriorityDestination, nil
+	case model.QueryPriorityDestination, model.QueryPriorityIncoming:
+		return priority, nil
+	}
+	return "", ErrInvalidQueryPriority
+}
+
+// applyUTM actualiza los parámetros UTM del enlace; los campos nil no cambian
+// y un string vacío elimina el parámetro
+func applyUTM(utm *model.UTM, input UTMInput) error {
+	fields := []struct {
+		value  *string
+		target *string
+	}{
+		{input.Source, &utm.Source},
+		{input.Medium, &utm.Medium},
+		{input.Campaign, &utm.Campaign},
+		{input.Term, &utm.Term},
+		{input.Content, &utm.Content},
+	}
+
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+		value := strings.TrimSpace(*field.value)
+		if len(value) > maxUTMLength {
+			return ErrInvalidUTM
+		}
+		*field.target = value
+	}
+
+	return nil
+}
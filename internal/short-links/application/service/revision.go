@@ -0,0 +1,175 @@
+package service
+
+import (
+	"errors"
+	"short-go/internal/short-links/domain/model"
+	"short-go/internal/short-links/domain/repository"
+	"time"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("revisión no encontrada")
+	ErrRevisionExpired  = errors.New("la revisión tiene una fecha de expiración ya vencida")
+)
+
+// ListRevisions - Historial de cambios de un enlace, del más reciente al más antiguo
+func (s *ShortLinkService) ListRevisions(code string, access LinkAccess, page, pageSize int) (*model.RevisionPage, error) {
+	if _, err := s.findManaged(code, access); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	revisions, total, err := s.shortLinkRepo.FindRevisions(code, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RevisionPage{
+		Items:    revisions,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// RestoreRevision - Vuelve el enlace a la configuración guardada en una
+// revisión. La restauración queda registrada como una revisión nueva
+func (s *ShortLinkService) RestoreRevision(code string, access LinkAccess, revisionID uint) (*model.ShortLink, error) {
+	shortLink, err := s.findManaged(code, access)
+	if err != nil {
+		return nil, err
+	}
+
+	if shortLink.ModerationStatus == model.ModerationBanned {
+		return nil, ErrShortLinkBanned
+	}
+
+	revision, err := s.shortLinkRepo.FindRevision(code, revisionID)
+	if err != nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	if revision.Settings.ExpiresAt != nil && time.Now().After(*revision.Settings.ExpiresAt) {
+		return nil, ErrRevisionExpired
+	}
+
+	before := shortLink.Settings()
+	shortLink.ApplySettings(revision.Settings)
+
+	// Un dominio que el dueño ya no tiene verificado devuelve el enlace al
+	// dominio compartido, igual que al eliminar el dominio
+	if domain, err := s.resolveLinkDomain(shortLink.Domain, shortLink.UserID); err == nil {
+		shortLink.Domain = domain
+	} else {
+		shortLink.Domain = ""
+	}
+
+	// Los destinos se revisan de nuevo: las listas pueden haber cambiado
+	if err := s.screenDestinations(shortLink); err != nil {
+		return nil, err
+	}
+
+	shortLink.UpdatedAt = time.Now()
+
+	if err := s.saveWithRevision(shortLink, before, access, model.RevisionRestore, &revision.ID); err != nil {
+		return nil, err
+	}
+
+	return shortLink, nil
+}
+
+// saveWithRevision guarda la edición y su revisión en una sola transacción.
+// Si el enlace aún no tiene historial, antes se guarda su estado previo
+func (s *ShortLinkService) saveWithRevision(shortLink *model.ShortLink, before model.LinkSettings, access LinkAccess, action string, restoredFrom *uint) error {
+	return s.shortLinkRepo.Transaction(func(repo repository.ShortLinkRepository) error {
+		if err := ensureBaseline(repo, shortLink.Code, before); err != nil {
+			return err
+		}
+
+		if err := repo.Update(shortLink); err != nil {
+			return err
+		}
+
+		revision := newRevision(shortLink, access, action)
+		revision.OldURL = before.OriginalURL
+		revision.RestoredFromID = restoredFrom
+		return repo.CreateRevision(revision)
+	})
+}
+
+// recordStateChange aplica un cambio de estado del enlace (archivar, mover a
+// la papelera, sacarlo de ella o moderarlo) y guarda revision en la misma
+// transacción. change indica si encontró el enlace
+func recordStateChange(shortLinkRepo repository.ShortLinkRepository, shortLink *model.ShortLink, revision *model.Revision, change func(repo repository.ShortLinkRepository) (bool, error)) error {
+	return shortLinkRepo.Transaction(func(repo repository.ShortLinkRepository) error {
+		if err := ensureBaseline(repo, shortLink.Code, shortLink.Settings()); err != nil {
+			return err
+		}
+
+		changed, err := change(repo)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			return ErrShortLinkNotFound
+		}
+
+		revision.OldURL = shortLink.OriginalURL
+		return repo.CreateRevision(revision)
+	})
+}
+
+// ensureBaseline guarda el estado previo de un enlace creado antes del
+// historial como revisión baseline, si aún no tiene revisiones
+func ensureBaseline(repo repository.ShortLinkRepository, code string, before model.LinkSettings) error {
+	hasRevisions, err := repo.HasRevisions(code)
+	if err != nil || hasRevisions {
+		return err
+	}
+
+	return repo.CreateRevision(&model.Revision{
+		LinkCode:  code,
+		Action:    model.RevisionBaseline,
+		NewURL:    before.OriginalURL,
+		Settings:  before,
+		CreatedAt: time.Now(),
+	})
+}
+
+// creatorAccess identifica al creador de un enlace nuevo como autor de su
+// primera revisión
+func creatorAccess(shortLink *model.ShortLink) LinkAccess {
+	if shortLink.UserID == nil {
+		return LinkAccess{}
+	}
+	return LinkAccess{UserID: *shortLink.UserID}
+}
+
+// newRevision arma la revisión con el estado actual del enlace y el autor
+func newRevision(shortLink *model.ShortLink, access LinkAccess, action string) *model.Revision {
+	revision := &model.Revision{
+		LinkCode:  shortLink.Code,
+		Action:    action,
+		NewURL:    shortLink.OriginalURL,
+		Settings:  shortLink.Settings(),
+		ActorType: model.RevisionActorToken,
+		CreatedAt: time.Now(),
+	}
+
+	if access.UserID != "" && shortLink.UserID != nil && *shortLink.UserID == access.UserID {
+		userID := access.UserID
+		revision.ActorType = model.RevisionActorUser
+		revision.ActorUserID = &userID
+	}
+
+	return revision
+}
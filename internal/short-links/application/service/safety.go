@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"short-go/internal/short-links/domain/model"
+)
+
+var ErrUnsafeURL = errors.New("la URL de destino fue bloqueada por seguridad")
+
+// SafetyVerdict clasifica una URL de destino
+type SafetyVerdict int
+
+const (
+	SafetyClean      SafetyVerdict = iota
+	SafetySuspicious               // se permite, pero el enlace queda en cuarentena
+	SafetyMalicious                // se rechaza
+)
+
+// SafetyResult es la respuesta de un SafetyChecker; Reason explica la
+// clasificación cuando la URL no está limpia
+type SafetyResult struct {
+	Verdict SafetyVerdict
+	Reason  string
+}
+
+// SafetyChecker revisa una URL de destino. Las listas locales y cualquier
+// servicio de reputación externo implementan esta interfaz
+type SafetyChecker interface {
+	Check(rawURL string) (SafetyResult, error)
+}
+
+// MultiSafetyChecker combina varios checkers y devuelve el veredicto más
+// grave. Un checker que falla no impide consultar al resto: se devuelve el
+// veredicto obtenido junto con el primer error
+type MultiSafetyChecker []SafetyChecker
+
+func (m MultiSafetyChecker) Check(rawURL string) (SafetyResult, error) {
+	worst := SafetyResult{Verdict: SafetyClean}
+	var firstErr error
+	for _, checker := range m {
+		result, err := checker.Check(rawURL)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if result.Verdict > worst.Verdict {
+			worst = result
+		}
+	}
+	return worst, firstErr
+}
+
+// screenDestinations revisa todas las URLs de destino del enlace. Una URL
+// maliciosa rechaza el enlace; una sospechosa lo deja en cuarentena. Si un
+// checker falla se registra y el enlace no se bloquea, para que una caída de
+// un servicio externo no impida crear ni editar enlaces; sin una revisión
+// completa el enlace conserva su estado de cuarentena actual
+func (s *ShortLinkService) screenDestinations(shortLink *model.ShortLink) error {
+	quarantineReason := ""
+	incomplete := false
+
+	for _, destination := range destinationURLs(shortLink) {
+		result, err := s.safetyChecker.Check(destination)
+		if err != nil {
+			log.Printf("Warning: no se pudo revisar la seguridad de %q: %v", destination, err)
+			incomplete = true
+		}
+
+		switch result.Verdict {
+		case SafetyMalicious:
+			return ErrUnsafeURL
+		case SafetySuspicious:
+			if quarantineReason == "" {
+				quarantineReason = result.Reason
+			}
+		}
+	}
+
+	if quarantineReason != "" {
+		shortLink.Quarantined = true
+		shortLink.QuarantineReason = quarantineReason
+		return nil
+	}
+
+	// Una revisión parcial no basta para sacar un enlace de cuarentena
+	if incomplete {
+		return nil
+	}
+
+	shortLink.Quarantined = false
+	shortLink.QuarantineReason = ""
+	return nil
+}
+
+// destinationURLs lista todas las URLs a las que el enlace puede redirigir
+func destinationURLs(shortLink *model.ShortLink) []string {
+	urls := []string{shortLink.OriginalURL}
+	if shortLink.InactiveURL != "" {
+		urls = append(urls, shortLink.InactiveURL)
+	}
+	for _, rule := range shortLink.GeoRules {
+		urls = append(urls, rule.URL)
+	}
+	for _, rule := range shortLink.DeviceRules {
+		urls = append(urls, rule.URL)
+	}
+	for _, variant := range shortLink.Variants {
+		urls = append(urls, variant.URL)
+	}
+	return urls
+}
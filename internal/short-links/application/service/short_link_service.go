@@ -1,49 +1,342 @@
 package service
 
 import (
-	"crypto/rand"
+	"crypto/subtle"
 	"errors"
-	"math/big"
+	"fmt"
+	"regexp"
+	domainRepository "short-go/internal/domains/domain/repository"
 	"short-go/internal/short-links/domain/model"
 	"short-go/internal/short-links/domain/repository"
+	"strings"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 // Errores de dominio
 var (
 	ErrShortLinkNotFound      = errors.New("enlace corto no encontrado")
 	ErrUnauthorizedAccess     = errors.New("acceso no autorizado al enlace corto")
-	ErrInvalidOriginalURL     = errors.New("URL original inválida")
+	ErrInvalidOriginalURL     = errors.New("URL de destino inválida: usa una URL absoluta con dominio, por ejemplo https://ejemplo.com")
 	ErrManagementTokenInvalid = errors.New("token de gestión inválido")
+	ErrShortLinkExpired       = errors.New("el enlace corto ha expirado")
+	ErrClickLimitReached      = errors.New("el enlace alcanzó su límite de clicks")
+	ErrShortLinkNotYetActive  = errors.New("el enlace aún no está disponible")
+	ErrShortLinkEnded         = errors.New("la campaña del enlace ya terminó")
+	ErrShortLinkDisabled      = errors.New("el enlace fue deshabilitado por moderación")
+	ErrShortLinkBanned        = errors.New("el enlace fue bloqueado por moderación y no puede modificarse")
+	ErrShortLinkArchived      = errors.New("el enlace está archivado")
+
+	ErrAliasRequiresAuth = errors.New("debes iniciar sesión para elegir un alias personalizado")
+	ErrAliasInvalid      = errors.New("el alias debe tener entre 3 y 32 caracteres: letras, números, '-' o '_'")
+	ErrAliasReserved     = errors.New("el alias está reservado por el sistema")
+	ErrAliasTaken        = errors.New("el alias ya está en uso")
+
+	ErrInvalidExpiration = errors.New("la fecha de expiración debe ser futura")
+	ErrLinkPasswordShort = errors.New("la contraseña del enlace debe tener al menos 4 caracteres")
+	ErrLinkPasswordLong  = errors.New("la contraseña del enlace admite hasta 72 bytes")
+	ErrInvalidMaxClicks  = errors.New("el límite de clicks debe ser mayor a 0")
+	ErrInvalidSchedule   = errors.New("endsAt debe ser posterior a startsAt")
+	ErrInvalidRedirect   = errors.New("el tipo de redirección debe ser 301, 302, 307 o 308")
+
+	ErrCodeGeneration     = errors.New("no se pudo generar el código del enlace")
+	ErrCodeSpaceExhausted = errors.New("no se encontró un código disponible, intenta nuevamente")
+)
+
+// Parámetros de generación de códigos
+const (
+	defaultCodeLength     = 6
+	maxCodeLength         = 12
+	managementTokenLength = 16
+	// Intentos máximos por enlace antes de rendirse
+	maxCreateAttempts = 8
+	// Colisiones toleradas en una misma longitud antes de crecer
+	collisionsPerLength = 2
+)
+
+const minLinkPasswordLength = 4
+
+// bcrypt no admite contraseñas de más de 72 bytes
+const maxLinkPasswordBytes = 72
+
+// Paginación del listado de enlaces
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
 )
 
+// LinkAccess identifica a quien intenta gestionar un enlace: el dueño
+// autenticado o el portador del token de gestión
+type LinkAccess struct {
+	UserID          string
+	ManagementToken string
+}
+
+// ClaimResult resume el reclamo de enlaces anónimos
+type ClaimResult struct {
+	Claimed  []*model.ShortLink
+	Rejected int
+}
+
+// CreateShortLinkInput contiene los datos para crear un enlace
+type CreateShortLinkInput struct {
+	OriginalURL string
+	Alias       string
+	UserID      *string
+	Expiration  ExpirationInput
+	// Contraseña opcional para acceder al enlace
+	Password string
+	// Límite de clicks opcional (1 = enlace de un solo uso)
+	MaxClicks   *int64
+	Schedule    ScheduleInput
+	GeoRules    []model.GeoRule
+	DeviceRules []model.DeviceRule
+	Variants    []model.Variant
+	// Código de redirección (0 = 302)
+	RedirectType int
+	Passthrough  PassthroughInput
+	UTM          UTMInput
+	Title        string
+	Tags         []string
+	Folder       string
+	// Dominio personalizado verificado de la cuenta; "" = dominio compartido
+	Domain string
+}
+
+// UpdateShortLinkInput contiene los campos editables de un enlace;
+// los campos nil se dejan sin cambios
+type UpdateShortLinkInput struct {
+	OriginalURL *string
+	Expiration  *ExpirationInput
+	// Un string vacío elimina la contraseña
+	Password *string
+	// 0 elimina el límite de clicks
+	MaxClicks    *int64
+	RedirectType *int
+	Passthrough  *PassthroughInput
+	UTM          *UTMInput
+	Schedule     *ScheduleInput
+	// Un slice vacío elimina las reglas; nil las deja sin cambios
+	GeoRules    *[]model.GeoRule
+	DeviceRules *[]model.DeviceRule
+	Variants    *[]model.Variant
+	// "" elimina el título o saca el enlace de su carpeta; [] quita las etiquetas
+	Title  *string
+	Tags   *[]string
+	Folder *string
+	// "" devuelve el enlace al dominio compartido
+	Domain *string
+}
+
+// PassthroughInput configura el reenvío de query string y ruta al destino.
+// En una edición los campos nil se dejan sin cambios
+type PassthroughInput struct {
+	ForwardQuery  *bool
+	QueryPriority *string
+	ForwardPath   *bool
+}
+
+// UTMInput contiene los parámetros UTM del enlace. Los campos nil no cambian
+type UTMInput struct {
+	Source   *string
+	Medium   *string
+	Campaign *string
+	Term     *string
+	Content  *string
+}
+
+// ScheduleInput define la ventana de activación de un enlace y el destino
+// alternativo para visitas fuera de ella
+type ScheduleInput struct {
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	InactiveURL *string
+	// Clear elimina la ventana y el destino alternativo
+	Clear bool
+}
+
+// aliasPattern define los caracteres y longitud permitidos para un alias
+var aliasPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{2,31}$`)
+
+// reservedAliases protege rutas propias del servidor y nombres sensibles
+var reservedAliases = map[string]struct{}{
+	"api":      {},
+	"health":   {},
+	"admin":    {},
+	"auth":     {},
+	"login":    {},
+	"logout":   {},
+	"register": {},
+	"stats":    {},
+	"qr":       {},
+	"static":   {},
+	"assets":   {},
+	// Rutas estáticas bajo /api/short-links que ocultarían al enlace
+	"tags":    {},
+	"folders": {},
+	"trash":   {},
+}
+
 type ShortLinkService struct {
-	shortLinkRepo repository.ShortLinkRepository
+	shortLinkRepo    repository.ShortLinkRepository
+	codeGenerator    CodeGenerator
+	expirationPolicy ExpirationPolicy
+	urlValidator     *URLValidator
+	safetyChecker    SafetyChecker
+	domainRepo       domainRepository.DomainRepository
+	// Tiempo que un enlace eliminado se puede restaurar desde la papelera
+	trashRetention time.Duration
+
+	// Longitud actual de los códigos aleatorios; crece cuando hay muchas colisiones
+	codeLength atomic.Int32
 }
 
-func NewShortLinkService(shortLinkRepo repository.ShortLinkRepository) *ShortLinkService {
-	return &ShortLinkService{shortLinkRepo: shortLinkRepo}
+func NewShortLinkService(
+	shortLinkRepo repository.ShortLinkRepository,
+	codeGenerator CodeGenerator,
+	expirationPolicy ExpirationPolicy,
+	urlValidator *URLValidator,
+	safetyChecker SafetyChecker,
+	domainRepo domainRepository.DomainRepository,
+	trashRetention time.Duration,
+) *ShortLinkService {
+	s := &ShortLinkService{
+		shortLinkRepo:    shortLinkRepo,
+		codeGenerator:    codeGenerator,
+		expirationPolicy: expirationPolicy,
+		urlValidator:     urlValidator,
+		safetyChecker:    safetyChecker,
+		domainRepo:       domainRepo,
+		trashRetention:   trashRetention,
+	}
+	s.codeLength.Store(defaultCodeLength)
+
+	return s
 }
 
-func (s *ShortLinkService) CreateShortLink(originalURL string, userID *string) (*model.ShortLink, error) {
-	if originalURL == "" {
-		return nil, ErrInvalidOriginalURL
+func (s *ShortLinkService) CreateShortLink(input CreateShortLinkInput) (*model.ShortLink, error) {
+	newShortLink, err := s.buildShortLink(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// El enlace y su primera revisión se guardan juntos
+	err = s.shortLinkRepo.Transaction(func(repo repository.ShortLinkRepository) error {
+		if err := s.insertWithUniqueCode(repo, newShortLink, input.Alias); err != nil {
+			return err
+		}
+		return repo.CreateRevision(newRevision(newShortLink, creatorAccess(newShortLink), model.RevisionCreate))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newShortLink, nil
+}
+
+// buildShortLink valida los datos de creación y arma el enlace, sin persistirlo
+func (s *ShortLinkService) buildShortLink(input CreateShortLinkInput) (*model.ShortLink, error) {
+	originalURL, err := s.urlValidator.Normalize(input.OriginalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Alias != "" {
+		if err := s.validateAlias(input.Alias, input.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	expiresAt, err := s.expirationPolicy.resolve(input.Expiration, input.UserID != nil, now)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := hashLinkPassword(input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.MaxClicks != nil && *input.MaxClicks < 1 {
+		return nil, ErrInvalidMaxClicks
+	}
+
+	redirectType, err := normalizeRedirectType(input.RedirectType)
+	if err != nil {
+		return nil, err
+	}
+
+	geoRules, err := s.normalizeGeoRules(input.GeoRules)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceRules, err := s.normalizeDeviceRules(input.DeviceRules)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := s.normalizeVariants(input.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := normalizeTitle(input.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return nil, err
 	}
 
-	codeManagement := generateRandomString(6)
-	managementToken := generateRandomString(16)
+	folder, err := normalizeFolder(input.Folder)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := s.resolveLinkDomain(input.Domain, input.UserID)
+	if err != nil {
+		return nil, err
+	}
 
 	newShortLink := &model.ShortLink{
-		Code:            codeManagement,
-		OriginalURL:     originalURL,
-		ManagementToken: managementToken,
-		ExpiresAt:       time.Now().AddDate(0, 2, 0),
-		UserID:          userID,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		OriginalURL:      originalURL,
+		ExpiresAt:        expiresAt,
+		UserID:           input.UserID,
+		PasswordHash:     passwordHash,
+		MaxClicks:        input.MaxClicks,
+		RedirectType:     redirectType,
+		QueryPriority:    model.QueryPriorityDestination,
+		ModerationStatus: model.ModerationActive,
+		GeoRules:         geoRules,
+		DeviceRules:      deviceRules,
+		Variants:         variants,
+		Title:            title,
+		Tags:             tags,
+		Folder:           folder,
+		Domain:           domain,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}
 
-	if err := s.shortLinkRepo.Create(newShortLink); err != nil {
+	if err := s.applySchedule(newShortLink, input.Schedule); err != nil {
+		return nil, err
+	}
+
+	if err := applyPassthrough(newShortLink, input.Passthrough); err != nil {
+		return nil, err
+	}
+
+	if err := applyUTM(&newShortLink.UTM, input.UTM); err != nil {
+		return nil, err
+	}
+
+	if err := s.screenDestinations(newShortLink); err != nil {
 		return nil, err
 	}
 
@@ -58,17 +351,527 @@ func (s *ShortLinkService) GetShortLinkByCode(code string) (*model.ShortLink, er
 	return shortLink, nil
 }
 
+// ResolveRedirect obtiene el enlace a redirigir y verifica que siga vigente.
+// host es el dominio personalizado por el que llegó la visita ("" para el
+// dominio compartido)
+func (s *ShortLinkService) ResolveRedirect(host string, code string) (*model.ShortLink, error) {
+	shortLink, err := s.GetShortLinkByCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.servedOn(shortLink, host) {
+		return nil, ErrShortLinkNotFound
+	}
+
+	// La moderación tiene prioridad sobre cualquier otro estado del enlace
+	if shortLink.IsDisabled() {
+		return shortLink, ErrShortLinkDisabled
+	}
+
+	if shortLink.IsArchived() {
+		return shortLink, ErrShortLinkArchived
+	}
+
+	if shortLink.IsExpired() {
+		return shortLink, ErrShortLinkExpired
+	}
+
+	if shortLink.ClickLimitReached() {
+		return shortLink, ErrClickLimitReached
+	}
+
+	if shortLink.NotYetActive() {
+		return shortLink, ErrShortLinkNotYetActive
+	}
+
+	if shortLink.ActivationEnded() {
+		return shortLink, ErrShortLinkEnded
+	}
+
+	return shortLink, nil
+}
+
+// ConsumeClick descuenta un click del límite del enlace de forma atómica.
+// Los enlaces sin límite no consumen nada
+func (s *ShortLinkService) ConsumeClick(shortLink *model.ShortLink) error {
+	if shortLink.MaxClicks == nil {
+		return nil
+	}
+
+	consumed, err := s.shortLinkRepo.ConsumeClick(shortLink.Code)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrClickLimitReached
+	}
+
+	shortLink.ConsumedClicks++
+	return nil
+}
+
+// VerifyLinkPassword compara la contraseña ingresada por el visitante
+func (s *ShortLinkService) VerifyLinkPassword(shortLink *model.ShortLink, password string) bool {
+	if !shortLink.HasPassword() {
+		return true
+	}
+	return bcrypt.CompareHashAndPassword([]byte(shortLink.PasswordHash), []byte(password)) == nil
+}
+
+// ListUserShortLinks - Lista paginada de los enlaces de un usuario
+func (s *ShortLinkService) ListUserShortLinks(userID string, opts model.ListOptions) (*model.ShortLinkPage, error) {
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.PageSize < 1 {
+		opts.PageSize = defaultPageSize
+	}
+	if opts.PageSize > maxPageSize {
+		opts.PageSize = maxPageSize
+	}
+	if opts.SortBy != model.SortByClicks {
+		opts.SortBy = model.SortByCreatedAt
+	}
+
+	filter, err := normalizeListFilter(opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+	opts.Filter = filter
+
+	shortLinks, total, err := s.shortLinkRepo.FindByUserID(userID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ShortLinkPage{
+		Items:    shortLinks,
+		Total:    total,
+		Page:     opts.Page,
+		PageSize: opts.PageSize,
+	}, nil
+}
+
+// GetManagedShortLink - Obtiene un enlace del usuario o del portador del token
+func (s *ShortLinkService) GetManagedShortLink(code string, access LinkAccess) (*model.ShortLink, error) {
+	return s.findManaged(code, access)
+}
+
+// UpdateShortLink - Cambia el destino o la expiración de un enlace
+func (s *ShortLinkService) UpdateShortLink(code string, access LinkAccess, input UpdateShortLinkInput) (*model.ShortLink, error) {
+	shortLink, err := s.findManaged(code, access)
+	if err != nil {
+		return nil, err
+	}
+
+	if shortLink.ModerationStatus == model.ModerationBanned {
+		return nil, ErrShortLinkBanned
+	}
+
+	before := shortLink.Settings()
+
+	if input.OriginalURL != nil {
+		originalURL, err := s.urlValidator.Normalize(*input.OriginalURL)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.OriginalURL = originalURL
+	}
+
+	if input.Expiration != nil && !input.Expiration.isEmpty() {
+		// La política aplica según el dueño actual del enlace
+		expiresAt, err := s.expirationPolicy.resolve(*input.Expiration, shortLink.UserID != nil, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		shortLink.ExpiresAt = expiresAt
+	}
+
+	if input.Password != nil {
+		passwordHash, err := hashLinkPassword(*input.Password)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.PasswordHash = passwordHash
+	}
+
+	if input.MaxClicks != nil {
+		switch {
+		case *input.MaxClicks < 0:
+			return nil, ErrInvalidMaxClicks
+		case *input.MaxClicks == 0:
+			shortLink.MaxClicks = nil
+		default:
+			shortLink.MaxClicks = input.MaxClicks
+		}
+	}
+
+	if input.RedirectType != nil {
+		redirectType, err := normalizeRedirectType(*input.RedirectType)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.RedirectType = redirectType
+	}
+
+	if input.Passthrough != nil {
+		if err := applyPassthrough(shortLink, *input.Passthrough); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.UTM != nil {
+		if err := applyUTM(&shortLink.UTM, *input.UTM); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Schedule != nil {
+		if err := s.applySchedule(shortLink, *input.Schedule); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.GeoRules != nil {
+		geoRules, err := s.normalizeGeoRules(*input.GeoRules)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.GeoRules = geoRules
+	}
+
+	if input.DeviceRules != nil {
+		deviceRules, err := s.normalizeDeviceRules(*input.DeviceRules)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.DeviceRules = deviceRules
+	}
+
+	if input.Variants != nil {
+		variants, err := s.normalizeVariants(*input.Variants)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.Variants = variants
+	}
+
+	if input.Title != nil {
+		title, err := normalizeTitle(*input.Title)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.Title = title
+	}
+
+	if input.Tags != nil {
+		tags, err := normalizeTags(*input.Tags)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.Tags = tags
+	}
+
+	if input.Folder != nil {
+		folder, err := normalizeFolder(*input.Folder)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.Folder = folder
+	}
+
+	if input.Domain != nil {
+		// El dominio debe pertenecer al dueño del enlace, aunque edite el
+		// portador del token de gestión
+		domain, err := s.resolveLinkDomain(*input.Domain, shortLink.UserID)
+		if err != nil {
+			return nil, err
+		}
+		shortLink.Domain = domain
+	}
+
+	// Se revisan todos los destinos: las listas pueden haber cambiado desde
+	// la última edición
+	if err := s.screenDestinations(shortLink); err != nil {
+		return nil, err
+	}
+
+	shortLink.UpdatedAt = time.Now()
+
+	if err := s.saveWithRevision(shortLink, before, access, model.RevisionUpdate, nil); err != nil {
+		return nil, err
+	}
+
+	return shortLink, nil
+}
+
+// DeleteShortLink - Mueve un enlace a la papelera
+func (s *ShortLinkService) DeleteShortLink(code string, access LinkAccess) error {
+	shortLink, err := s.findManaged(code, access)
+	if err != nil {
+		return err
+	}
+
+	// Un enlace bloqueado se conserva para que su código no vuelva a usarse
+	if shortLink.ModerationStatus == model.ModerationBanned {
+		return ErrShortLinkBanned
+	}
+
+	return recordStateChange(s.shortLinkRepo, shortLink, newRevision(shortLink, access, model.RevisionDelete), func(repo repository.ShortLinkRepository) (bool, error) {
+		return true, repo.DeleteByCode(code)
+	})
+}
+
+// ClaimShortLinks - Transfiere al usuario los enlaces anónimos cuyos tokens
+// de gestión presenta. Los tokens inválidos o de enlaces con dueño se rechazan
+func (s *ShortLinkService) ClaimShortLinks(userID string, managementTokens []string) (*ClaimResult, error) {
+	result := &ClaimResult{Claimed: []*model.ShortLink{}}
+	seen := make(map[string]struct{}, len(managementTokens))
+
+	for _, token := range managementTokens {
+		if _, duplicated := seen[token]; duplicated {
+			continue
+		}
+		seen[token] = struct{}{}
+
+		shortLink, err := s.shortLinkRepo.FindByManagementToken(token)
+		if err != nil || shortLink.UserID != nil {
+			result.Rejected++
+			continue
+		}
+
+		claimed, err := s.shortLinkRepo.AssignOwner(shortLink.Code, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			result.Rejected++
+			continue
+		}
+
+		shortLink.UserID = &userID
+		shortLink.ManagementToken = ""
+		result.Claimed = append(result.Claimed, shortLink)
+	}
+
+	return result, nil
+}
+
 // ------------------------------ HELPERS -----------------------------------
-func generateRandomString(length int) string {
-    const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-    b := make([]byte, length)
-    for i := range b {
-        // Usa crypto/rand para obtener un número seguro
-        num, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
-        if err != nil {
-            return "" // Manejar error si es crítico, o retornar string vacío
-        }
-        b[i] = charset[num.Int64()]
-    }
-    return string(b)
+// insertWithUniqueCode asigna código y token de gestión y persiste el enlace,
+// reintentando con un código nuevo cuando el repositorio detecta una colisión.
+// repo permite insertar dentro de una transacción
+func (s *ShortLinkService) insertWithUniqueCode(repo repository.ShortLinkRepository, shortLink *model.ShortLink, alias string) error {
+	length := int(s.codeLength.Load())
+	collisions := 0
+
+	for attempt := 0; attempt < maxCreateAttempts; attempt++ {
+		// Solo los enlaces anónimos reciben token; los de una cuenta los
+		// gestiona su dueño
+		if shortLink.UserID == nil {
+			managementToken, err := s.codeGenerator.Generate(managementTokenLength)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrCodeGeneration, err)
+			}
+			shortLink.ManagementToken = managementToken
+		}
+
+		if alias != "" {
+			shortLink.Code = alias
+		} else {
+			code, err := s.codeGenerator.Generate(length)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrCodeGeneration, err)
+			}
+			shortLink.Code = code
+		}
+
+		err := repo.Create(shortLink)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, repository.ErrDuplicateCode) {
+			return err
+		}
+
+		// Un alias elegido por el usuario no se reintenta con otro valor,
+		// salvo que la colisión haya sido del token de gestión
+		if alias != "" {
+			if taken, findErr := repo.CodeTaken(alias); findErr == nil && taken {
+				return ErrAliasTaken
+			}
+			continue
+		}
+
+		collisions++
+		if collisions%collisionsPerLength == 0 && length < maxCodeLength {
+			length++
+			s.growCodeLength(length)
+		}
+	}
+
+	return ErrCodeSpaceExhausted
+}
+
+// growCodeLength eleva la longitud compartida para que los próximos enlaces
+// arranquen en un espacio de códigos menos saturado
+func (s *ShortLinkService) growCodeLength(length int) {
+	for {
+		current := s.codeLength.Load()
+		if int32(length) <= current {
+			return
+		}
+		if s.codeLength.CompareAndSwap(current, int32(length)) {
+			return
+		}
+	}
+}
+
+// findManaged busca un enlace y verifica que quien accede sea su dueño
+// o presente el token de gestión correcto
+func (s *ShortLinkService) findManaged(code string, access LinkAccess) (*model.ShortLink, error) {
+	if access.UserID == "" && access.ManagementToken == "" {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	shortLink, err := s.shortLinkRepo.FindByCode(code)
+	if err != nil {
+		return nil, ErrShortLinkNotFound
+	}
+
+	if err := authorizeAccess(shortLink, access); err != nil {
+		return nil, err
+	}
+	return shortLink, nil
+}
+
+// authorizeAccess verifica que access sea el dueño del enlace o el portador
+// de su token de gestión
+func authorizeAccess(shortLink *model.ShortLink, access LinkAccess) error {
+	if access.UserID != "" && shortLink.UserID != nil && *shortLink.UserID == access.UserID {
+		return nil
+	}
+
+	if access.ManagementToken != "" {
+		if subtle.ConstantTimeCompare([]byte(access.ManagementToken), []byte(shortLink.ManagementToken)) == 1 {
+			return nil
+		}
+		return ErrManagementTokenInvalid
+	}
+
+	return ErrUnauthorizedAccess
+}
+
+// applySchedule aplica la ventana de activación solicitada al enlace
+func (s *ShortLinkService) applySchedule(shortLink *model.ShortLink, schedule ScheduleInput) error {
+	if schedule.Clear {
+		shortLink.StartsAt = nil
+		shortLink.EndsAt = nil
+		shortLink.InactiveURL = ""
+		return nil
+	}
+
+	startsAt, endsAt := shortLink.StartsAt, shortLink.EndsAt
+	if schedule.StartsAt != nil {
+		startsAt = schedule.StartsAt
+	}
+	if schedule.EndsAt != nil {
+		endsAt = schedule.EndsAt
+	}
+
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return ErrInvalidSchedule
+	}
+
+	shortLink.StartsAt = startsAt
+	shortLink.EndsAt = endsAt
+	if schedule.InactiveURL != nil {
+		inactiveURL := ""
+		if *schedule.InactiveURL != "" {
+			normalized, err := s.urlValidator.Normalize(*schedule.InactiveURL)
+			if err != nil {
+				return err
+			}
+			inactiveURL = normalized
+		}
+		shortLink.InactiveURL = inactiveURL
+	}
+
+	return nil
+}
+
+// applyPassthrough aplica la configuración de reenvío; los campos nil no cambian
+func applyPassthrough(shortLink *model.ShortLink, passthrough PassthroughInput) error {
+	if passthrough.QueryPriority != nil {
+		priority, err := normalizeQueryPriority(*passthrough.QueryPriority)
+		if err != nil {
+			return err
+		}
+		shortLink.QueryPriority = priority
+	}
+	if passthrough.ForwardQuery != nil {
+		shortLink.ForwardQuery = *passthrough.ForwardQuery
+	}
+	if passthrough.ForwardPath != nil {
+		shortLink.ForwardPath = *passthrough.ForwardPath
+	}
+	return nil
+}
+
+// normalizeRedirectType valida el código de redirección; 0 usa 302
+func normalizeRedirectType(redirectType int) (int, error) {
+	switch redirectType {
+	case 0:
+		return model.RedirectFound, nil
+	case model.RedirectMovedPermanently, model.RedirectFound,
+		model.RedirectTemporary, model.RedirectPermanentRedirect:
+		return redirectType, nil
+	}
+	return 0, ErrInvalidRedirect
+}
+
+// hashLinkPassword valida y hashea la contraseña de un enlace.
+// Una contraseña vacía significa enlace público
+func hashLinkPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	if len(password) < minLinkPasswordLength {
+		return "", ErrLinkPasswordShort
+	}
+
+	if len(password) > maxLinkPasswordBytes {
+		return "", ErrLinkPasswordLong
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashed), nil
+}
+
+// validateAlias verifica formato, palabras reservadas y disponibilidad del alias
+func (s *ShortLinkService) validateAlias(alias string, userID *string) error {
+	if userID == nil {
+		return ErrAliasRequiresAuth
+	}
+
+	if !aliasPattern.MatchString(alias) {
+		return ErrAliasInvalid
+	}
+
+	if _, reserved := reservedAliases[strings.ToLower(alias)]; reserved {
+		return ErrAliasReserved
+	}
+
+	// Un código en la papelera sigue reservado hasta que se purga
+	if taken, err := s.shortLinkRepo.CodeTaken(alias); err == nil && taken {
+		return ErrAliasTaken
+	}
+
+	return nil
 }
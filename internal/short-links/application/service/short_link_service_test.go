@@ -0,0 +1,163 @@
+package service
+
+import (
+	"errors"
+	"short-go/internal/short-links/domain/model"
+	"short-go/internal/short-links/domain/repository"
+	"sync"
+	"testing"
+)
+
+// fakeShortLinkRepository implementa solo los métodos que usan estas
+// pruebas; el resto queda en la interfaz embebida y falla si se llama
+type fakeShortLinkRepository struct {
+	repository.ShortLinkRepository
+
+	mu       sync.Mutex
+	links    map[string]*model.ShortLink
+	consumes int
+	err      error
+}
+
+func newFakeShortLinkRepository(links ...*model.ShortLink) *fakeShortLinkRepository {
+	repo := &fakeShortLinkRepository{links: make(map[string]*model.ShortLink)}
+	for _, link := range links {
+		stored := *link
+		repo.links[link.Code] = &stored
+	}
+	return repo
+}
+
+func (r *fakeShortLinkRepository) CodeTaken(code string) (bool, error) {
+	_, taken := r.links[code]
+	return taken, r.err
+}
+
+// ConsumeClick replica la condición del UPDATE: solo incrementa si no se
+// alcanzó el límite
+func (r *fakeShortLinkRepository) ConsumeClick(code string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.consumes++
+	if r.err != nil {
+		return false, r.err
+	}
+
+	link, ok := r.links[code]
+	if !ok || (link.MaxClicks != nil && link.ConsumedClicks >= *link.MaxClicks) {
+		return false, nil
+	}
+	link.ConsumedClicks++
+	return true, nil
+}
+
+func newTestService(repo repository.ShortLinkRepository) *ShortLinkService {
+	return NewShortLinkService(repo, nil, ExpirationPolicy{}, nil, nil, nil, 0)
+}
+
+func TestValidateAlias(t *testing.T) {
+	userID := "user-1"
+	repo := newFakeShortLinkRepository(&model.ShortLink{Code: "ocupado"})
+	s := newTestService(repo)
+
+	tests := []struct {
+		name    string
+		alias   string
+		userID  *string
+		wantErr error
+	}{
+		{name: "válido", alias: "mi-enlace_2", userID: &userID},
+		{name: "anónimo", alias: "mi-enlace", wantErr: ErrAliasRequiresAuth},
+		{name: "muy corto", alias: "ab", userID: &userID, wantErr: ErrAliasInvalid},
+		{name: "muy largo", alias: "a123456789012345678901234567890123", userID: &userID, wantErr: ErrAliasInvalid},
+		{name: "empieza con guión", alias: "-enlace", userID: &userID, wantErr: ErrAliasInvalid},
+		{name: "caracteres no permitidos", alias: "mi/enlace", userID: &userID, wantErr: ErrAliasInvalid},
+		{name: "ruta del servidor", alias: "api", userID: &userID, wantErr: ErrAliasReserved},
+		{name: "reservado en mayúsculas", alias: "Admin", userID: &userID, wantErr: ErrAliasReserved},
+		{name: "ruta de etiquetas", alias: "tags", userID: &userID, wantErr: ErrAliasReserved},
+		{name: "ruta de carpetas", alias: "folders", userID: &userID, wantErr: ErrAliasReserved},
+		{name: "ruta de la papelera", alias: "TRASH", userID: &userID, wantErr: ErrAliasReserved},
+		{name: "en uso", alias: "ocupado", userID: &userID, wantErr: ErrAliasTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.validateAlias(tt.alias, tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateAlias(%q) = %v, se esperaba %v", tt.alias, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConsumeClick(t *testing.T) {
+	limit := int64(2)
+	repoErr := errors.New("base de datos caída")
+
+	tests := []struct {
+		name         string
+		maxClicks    *int64
+		consumed     int64
+		repoErr      error
+		wantErr      error
+		wantConsumed int64
+		wantRepoCall bool
+	}{
+		{name: "sin límite no consume", wantConsumed: 0},
+		{name: "dentro del límite", maxClicks: &limit, consumed: 1, wantConsumed: 2, wantRepoCall: true},
+		{name: "límite alcanzado", maxClicks: &limit, consumed: 2, wantErr: ErrClickLimitReached, wantConsumed: 2, wantRepoCall: true},
+		{name: "error del repositorio", maxClicks: &limit, repoErr: repoErr, wantErr: repoErr, wantRepoCall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link := &model.ShortLink{Code: "abc123", MaxClicks: tt.maxClicks, ConsumedClicks: tt.consumed}
+			repo := newFakeShortLinkRepository(link)
+			repo.err = tt.repoErr
+			s := newTestService(repo)
+
+			if err := s.ConsumeClick(link); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, se esperaba %v", err, tt.wantErr)
+			}
+			if link.ConsumedClicks != tt.wantConsumed {
+				t.Errorf("clicks consumidos = %d, se esperaba %d", link.ConsumedClicks, tt.wantConsumed)
+			}
+			if (repo.consumes > 0) != tt.wantRepoCall {
+				t.Errorf("llamadas al repositorio = %d", repo.consumes)
+			}
+		})
+	}
+}
+
+// Con visitas concurrentes solo pasan tantas como permite el límite
+func TestConsumeClickConcurrent(t *testing.T) {
+	limit := int64(3)
+	repo := newFakeShortLinkRepository(&model.ShortLink{Code: "abc123", MaxClicks: &limit})
+	s := newTestService(repo)
+
+	const visits = 20
+	results := make(chan error, visits)
+	var wg sync.WaitGroup
+	for i := 0; i < visits; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- s.ConsumeClick(&model.ShortLink{Code: "abc123", MaxClicks: &limit})
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	allowed := 0
+	for err := range results {
+		switch {
+		case err == nil:
+			allowed++
+		case !errors.Is(err, ErrClickLimitReached):
+			t.Fatalf("error inesperado: %v", err)
+		}
+	}
+	if allowed != int(limit) {
+		t.Errorf("visitas permitidas = %d, se esperaba %d", allowed, limit)
+	}
+}
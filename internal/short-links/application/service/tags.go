@@ -0,0 +1,40 @@
+package service
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidTags = errors.New("etiquetas inválidas: hasta 10 por enlace y de 1 a 32 caracteres cada una")
+
+const (
+	maxTagsPerLink = 10
+	maxTagLength   = 32
+)
+
+// normalizeTags pasa las etiquetas a minúsculas, quita espacios y duplicados
+// y conserva el orden en que llegaron
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len([]rune(tag)) > maxTagLength {
+			return nil, ErrInvalidTags
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTagsPerLink {
+		return nil, ErrInvalidTags
+	}
+	return normalized, nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"errors"
+	"math/rand/v2"
+	"regexp"
+	"short-go/internal/short-links/domain/model"
+	"strings"
+)
+
+var (
+	ErrInvalidGeoRules    = errors.New("reglas por país inválidas: cada regla necesita países ISO de 2 letras y una URL")
+	ErrInvalidDeviceRules = errors.New("reglas por dispositivo inválidas: plataformas permitidas ios, android, windows, macos, linux, other, mobile y desktop")
+	ErrInvalidVariants    = errors.New("variantes inválidas: se necesitan entre 2 y 10 con nombre único, URL y pesos positivos que sumen 100")
+)
+
+const (
+	minVariants          = 2
+	maxVariants          = 10
+	maxVariantNameLength = 32
+	totalVariantWeight   = 100
+)
+
+const maxTargetingRules = 20
+
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+var knownPlatforms = map[string]bool{
+	model.PlatformIOS:     true,
+	model.PlatformAndroid: true,
+	model.PlatformWindows: true,
+	model.PlatformMacOS:   true,
+	model.PlatformLinux:   true,
+	model.PlatformOther:   true,
+	model.PlatformMobile:  true,
+	model.PlatformDesktop: true,
+}
+
+// ResolveDestination elige el destino para el visitante. Las reglas por
+// dispositivo tienen prioridad (un deep link solo sirve en su plataforma),
+// luego las reglas por país y, si ninguna aplica, la rotación A/B o la URL original
+func (s *ShortLinkService) ResolveDestination(shortLink *model.ShortLink, visitor model.Visitor) model.Destination {
+	for _, rule := range shortLink.DeviceRules {
+		if rule.Matches(visitor.Platform) {
+			return model.Destination{URL: rule.URL}
+		}
+	}
+
+	country := strings.ToUpper(visitor.CountryCode)
+
+	for _, rule := range shortLink.GeoRules {
+		for _, ruleCountry := range rule.Countries {
+			if ruleCountry == country {
+				return model.Destination{URL: rule.URL}
+			}
+		}
+	}
+
+	if variant := pickVariant(shortLink.Variants); variant != nil {
+		return model.Destination{URL: variant.URL, Variant: variant.Name}
+	}
+
+	return model.Destination{URL: shortLink.OriginalURL}
+}
+
+// pickVariant elige una variante al azar respetando los pesos
+func pickVariant(variants []model.Variant) *model.Variant {
+	total := 0
+	for _, v := range variants {
+		total += v.Weight
+	}
+	if total <= 0 {
+		return nil
+	}
+
+	n := rand.IntN(total)
+	for i := range variants {
+		n -= variants[i].Weight
+		if n < 0 {
+			return &variants[i]
+		}
+	}
+
+	return nil
+}
+
+// normalizeGeoRules valida las reglas por país y normaliza los códigos a mayúsculas
+func (s *ShortLinkService) normalizeGeoRules(rules []model.GeoRule) ([]model.GeoRule, error) {
+	if len(rules) > maxTargetingRules {
+		return nil, ErrInvalidGeoRules
+	}
+
+	normalized := make([]model.GeoRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.URL == "" || len(rule.Countries) == 0 {
+			return nil, ErrInvalidGeoRules
+		}
+
+		countries := make([]string, len(rule.Countries))
+		for i, country := range rule.Countries {
+			countries[i] = strings.ToUpper(strings.TrimSpace(country))
+			if !countryCodePattern.MatchString(countries[i]) {
+				return nil, ErrInvalidGeoRules
+			}
+		}
+
+		ruleURL, err := s.urlValidator.Normalize(rule.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		normalized = append(normalized, model.GeoRule{Countries: countries, URL: ruleURL})
+	}
+
+	return normalized, nil
+}
+
+// normalizeDeviceRules valida las reglas por plataforma y normaliza los nombres a minúsculas
+func (s *ShortLinkService) normalizeDeviceRules(rules []model.DeviceRule) ([]model.DeviceRule, error) {
+	if len(rules) > maxTargetingRules {
+		return nil, ErrInvalidDeviceRules
+	}
+
+	normalized := make([]model.DeviceRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.URL == "" || len(rule.Platforms) == 0 {
+			return nil, ErrInvalidDeviceRules
+		}
+
+		platforms := make([]string, len(rule.Platforms))
+		for i, platform := range rule.Platforms {
+			platforms[i] = strings.ToLower(strings.TrimSpace(platform))
+			if !knownPlatforms[platforms[i]] {
+				return nil, ErrInvalidDeviceRules
+			}
+		}
+
+		ruleURL, err := s.urlValidator.NormalizeDeepLink(rule.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		normalized = append(normalized, model.DeviceRule{Platforms: platforms, URL: ruleURL})
+	}
+
+	return normalized, nil
+}
+
+// normalizeVariants valida las variantes A/B. Los nombres omitidos se
+// completan con letras (A, B, C...) según su posición
+func (s *ShortLinkService) normalizeVariants(variants []model.Variant) ([]model.Variant, error) {
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	if len(variants) < minVariants || len(variants) > maxVariants {
+		return nil, ErrInvalidVariants
+	}
+
+	normalized := make([]model.Variant, 0, len(variants))
+	seen := make(map[string]bool, len(variants))
+	totalWeight := 0
+
+	for i, variant := range variants {
+		name := strings.TrimSpace(variant.Name)
+		if name == "" {
+			name = string(rune('A' + i))
+		}
+
+		if variant.URL == "" || variant.Weight <= 0 || len(name) > maxVariantNameLength || seen[name] {
+			return nil, ErrInvalidVariants
+		}
+
+		variantURL, err := s.urlValidator.Normalize(variant.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		seen[name] = true
+		totalWeight += variant.Weight
+		normalized = append(normalized, model.Variant{Name: name, URL: variantURL, Weight: variant.Weight})
+	}
+
+	if totalWeight != totalVariantWeight {
+		return nil, ErrInvalidVariants
+	}
+
+	return normalized, nil
+}
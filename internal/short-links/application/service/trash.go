@@ -0,0 +1,98 @@
+package service
+
+import (
+	"short-go/internal/short-links/domain/model"
+	"short-go/internal/short-links/domain/repository"
+	"time"
+)
+
+// ListTrash - Enlaces del usuario en la papelera que aún se pueden restaurar
+func (s *ShortLinkService) ListTrash(userID string, page, pageSize int) (*model.ShortLinkPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	shortLinks, total, err := s.shortLinkRepo.FindDeletedByUserID(userID, s.trashCutoff(), page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ShortLinkPage{
+		Items:    shortLinks,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// RestoreDeletedShortLink - Saca un enlace de la papelera. Pasado el tiempo
+// de retención el enlace ya no se puede restaurar, aunque su código siga reservado
+func (s *ShortLinkService) RestoreDeletedShortLink(code string, access LinkAccess) (*model.ShortLink, error) {
+	if access.UserID == "" && access.ManagementToken == "" {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	shortLink, err := s.shortLinkRepo.FindDeletedByCode(code)
+	if err != nil || shortLink.DeletedAt == nil || !shortLink.DeletedAt.After(s.trashCutoff()) {
+		return nil, ErrShortLinkNotFound
+	}
+
+	if err := authorizeAccess(shortLink, access); err != nil {
+		return nil, err
+	}
+
+	err = recordStateChange(s.shortLinkRepo, shortLink, newRevision(shortLink, access, model.RevisionUndelete), func(repo repository.ShortLinkRepository) (bool, error) {
+		return repo.RestoreDeleted(code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shortLink.DeletedAt = nil
+	return shortLink, nil
+}
+
+// ArchiveShortLink - Archiva o desarchiva un enlace. Un enlace archivado deja
+// de redirigir pero conserva sus clicks y estadísticas
+func (s *ShortLinkService) ArchiveShortLink(code string, access LinkAccess, archived bool) (*model.ShortLink, error) {
+	shortLink, err := s.findManaged(code, access)
+	if err != nil {
+		return nil, err
+	}
+
+	if shortLink.ModerationStatus == model.ModerationBanned {
+		return nil, ErrShortLinkBanned
+	}
+
+	var archivedAt *time.Time
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+	}
+
+	action := model.RevisionUnarchive
+	if archived {
+		action = model.RevisionArchive
+	}
+
+	err = recordStateChange(s.shortLinkRepo, shortLink, newRevision(shortLink, access, action), func(repo repository.ShortLinkRepository) (bool, error) {
+		return repo.SetArchived(code, archivedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shortLink.ArchivedAt = archivedAt
+	return shortLink, nil
+}
+
+// trashCutoff es el borde de la papelera: lo eliminado antes ya no se restaura
+func (s *ShortLinkService) trashCutoff() time.Time {
+	return time.Now().Add(-s.trashRetention)
+}
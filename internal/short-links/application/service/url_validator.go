@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/url"
+	"short-go/internal/shared/urlutil"
+	"strings"
+)
+
+var (
+	ErrURLSchemeNotAllowed = errors.New("esquema de URL no permitido")
+	ErrURLTooLong          = errors.New("la URL supera el largo máximo permitido")
+	ErrRedirectLoop        = errors.New("la URL de destino no puede apuntar a este acortador")
+)
+
+const defaultMaxURLLength = 2048
+
+// URLPolicy define qué destinos se aceptan. DeepLinkSchemes se permiten
+// además de AllowedSchemes solo en las reglas por dispositivo
+type URLPolicy struct {
+	AllowedSchemes  []string
+	DeepLinkSchemes []string
+	MaxLength       int
+	// Dominios propios: un destino hacia ellos crearía un bucle de redirección
+	OwnHosts []string
+	// Dominios personalizados de las cuentas; cambian en ejecución, así que
+	// se consultan en cada validación
+	CustomHosts VerifiedHostChecker
+}
+
+// VerifiedHostChecker indica si un host es un dominio personalizado verificado
+type VerifiedHostChecker interface {
+	IsVerifiedHost(host string) (bool, error)
+}
+
+// URLValidator valida y normaliza las URLs de destino
+type URLValidator struct {
+	allowedSchemes  map[string]bool
+	deepLinkSchemes map[string]bool
+	maxLength       int
+	ownHosts        map[string]bool
+	customHosts     VerifiedHostChecker
+}
+
+func NewURLValidator(policy URLPolicy) *URLValidator {
+	v := &URLValidator{
+		allowedSchemes:  toSchemeSet(policy.AllowedSchemes),
+		deepLinkSchemes: toSchemeSet(policy.DeepLinkSchemes),
+		maxLength:       policy.MaxLength,
+		ownHosts:        map[string]bool{},
+		customHosts:     policy.CustomHosts,
+	}
+	if len(v.allowedSchemes) == 0 {
+		v.allowedSchemes = toSchemeSet([]string{"http", "https"})
+	}
+	if v.maxLength <= 0 {
+		v.maxLength = defaultMaxURLLength
+	}
+	for _, host := range policy.OwnHosts {
+		v.addOwnHost(host)
+	}
+	return v
+}
+
+// addOwnHost registra un dominio propio (acepta URL completa o solo el host)
+func (v *URLValidator) addOwnHost(host string) {
+	if strings.Contains(host, "://") {
+		if parsed, err := url.Parse(host); err == nil {
+			host = parsed.Hostname()
+		}
+	}
+	host = strings.ToLower(strings.TrimSpace(host))
+	if ascii, err := urlutil.HostToASCII(host); err == nil {
+		host = ascii
+	}
+	if host != "" {
+		v.ownHosts[host] = true
+	}
+}
+
+// Normalize valida una URL de destino y devuelve su forma normalizada:
+// esquema y dominio en minúsculas y dominios internacionales en Punycode
+func (v *URLValidator) Normalize(raw string) (string, error) {
+	return v.normalize(raw, false)
+}
+
+// NormalizeDeepLink es como Normalize pero también acepta los esquemas de
+// apertura de apps (itms-apps://, market://, intent://...)
+func (v *URLValidator) NormalizeDeepLink(raw string) (string, error) {
+	return v.normalize(raw, true)
+}
+
+func (v *URLValidator) normalize(raw string, allowDeepLinks bool) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", ErrInvalidOriginalURL
+	}
+	if len(raw) > v.maxLength {
+		return "", ErrURLTooLong
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" {
+		return "", ErrInvalidOriginalURL
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	parsed.Scheme = scheme
+
+	if !v.allowedSchemes[scheme] {
+		if allowDeepLinks && v.deepLinkSchemes[scheme] {
+			// Los deep links tienen formatos propios de cada plataforma
+			return parsed.String(), nil
+		}
+		return "", ErrURLSchemeNotAllowed
+	}
+
+	if scheme != "http" && scheme != "https" {
+		return parsed.String(), nil
+	}
+
+	if err := v.normalizeWebHost(parsed); err != nil {
+		return "", err
+	}
+
+	normalized := parsed.String()
+	if len(normalized) > v.maxLength {
+		return "", ErrURLTooLong
+	}
+
+	return normalized, nil
+}
+
+// normalizeWebHost exige un dominio completo o una IP, sin credenciales
+// (https://banco.com@otro.com) y que no sea un dominio propio
+func (v *URLValidator) normalizeWebHost(parsed *url.URL) error {
+	if parsed.Opaque != "" || parsed.User != nil {
+		return ErrInvalidOriginalURL
+	}
+
+	hostname := parsed.Hostname()
+	if hostname == "" {
+		return ErrInvalidOriginalURL
+	}
+
+	if ip := net.ParseIP(hostname); ip == nil {
+		ascii, err := urlutil.HostToASCII(hostname)
+		if err != nil || !strings.Contains(ascii, ".") {
+			return ErrInvalidOriginalURL
+		}
+		hostname = ascii
+	} else if strings.Contains(hostname, ":") {
+		hostname = "[" + hostname + "]"
+	}
+
+	if v.isOwnHost(strings.Trim(hostname, "[]")) {
+		return ErrRedirectLoop
+	}
+
+	if port := parsed.Port(); port != "" {
+		parsed.Host = hostname + ":" + port
+	} else {
+		parsed.Host = hostname
+	}
+
+	return nil
+}
+
+func (v *URLValidator) isOwnHost(host string) bool {
+	if v.ownHosts[host] || v.ownHosts[strings.TrimPrefix(host, "www.")] || v.ownHosts["www."+host] {
+		return true
+	}
+	if v.customHosts == nil {
+		return false
+	}
+
+	verified, err := v.customHosts.IsVerifiedHost(host)
+	if err != nil {
+		log.Printf("Warning: no se pudo consultar los dominios personalizados: %v", err)
+		return false
+	}
+	return verified
+}
+
+func toSchemeSet(schemes []string) map[string]bool {
+	set := make(map[string]bool, len(schemes))
+	for _, scheme := range schemes {
+		scheme = strings.ToLower(strings.TrimSpace(scheme))
+		if scheme != "" {
+			set[scheme] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// Estados de moderación de un enlace
+const (
+	ModerationActive   = "active"
+	ModerationDisabled = "disabled" // suspendido; el dueño aún puede editarlo
+	ModerationBanned   = "banned"   // bloqueado; el dueño ya no puede gestionarlo
+)
+
+// Motivos aceptados al reportar un enlace
+const (
+	ReportReasonPhishing      = "phishing"
+	ReportReasonMalware       = "malware"
+	ReportReasonSpam          = "spam"
+	ReportReasonInappropriate = "inappropriate"
+	ReportReasonOther         = "other"
+)
+
+// Estados de un reporte
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"  // se tomó una acción sobre el enlace
+	ReportStatusDismissed = "dismissed" // el enlace se restauró sin acción
+)
+
+// Report es un reporte de abuso enviado por un visitante
+type Report struct {
+	ID             uint       `json:"id"`
+	LinkCode       string     `json:"linkCode"`
+	Reason         string     `json:"reason"`
+	Details        string     `json:"details,omitempty"`
+	ReporterIP     string     `json:"reporterIp,omitempty"`
+	ReporterUserID *string    `json:"reporterUserId,omitempty"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	ResolvedAt     *time.Time `json:"resolvedAt,omitempty"`
+	ResolvedBy     *string    `json:"resolvedBy,omitempty"`
+}
+
+// ReportListOptions filtra y pagina el listado de reportes; Status vacío lista todos
+type ReportListOptions struct {
+	Status   string
+	Page     int
+	PageSize int
+}
+
+// ReportPage es una página de reportes junto con el total
+type ReportPage struct {
+	Items    []*Report `json:"items"`
+	Total    int64     `json:"total"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"pageSize"`
+}
+
+// Moderation es la acción de un administrador sobre un enlace
+type Moderation struct {
+	Status      string
+	Reason      string
+	ModeratedBy string
+	ModeratedAt time.Time
+}
@@ -0,0 +1,125 @@
+package model
+
+import "time"
+
+// Acciones que generan una revisión
+const (
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionRestore = "restore"
+	// Cambios de estado: la configuración no cambia
+	RevisionArchive   = "archive"
+	RevisionUnarchive = "unarchive"
+	RevisionDelete    = "delete"
+	RevisionUndelete  = "undelete"
+	// Acciones de moderación de un admin
+	RevisionDisable   = "disable"
+	RevisionBan       = "ban"
+	RevisionReinstate = "reinstate"
+	// Estado previo de un enlace creado antes del historial, guardado en su
+	// primera edición para poder volver a él
+	RevisionBaseline = "baseline"
+)
+
+// Quién hizo el cambio: el dueño autenticado, el portador del token de
+// gestión o un admin que moderó el enlace
+const (
+	RevisionActorUser  = "user"
+	RevisionActorToken = "token"
+	RevisionActorAdmin = "admin"
+)
+
+// LinkSettings es la configuración editable de un enlace en un momento dado
+type LinkSettings struct {
+	OriginalURL   string
+	ExpiresAt     *time.Time
+	PasswordHash  string
+	MaxClicks     *int64
+	RedirectType  int
+	ForwardQuery  bool
+	QueryPriority string
+	ForwardPath   bool
+	UTM           UTM
+	StartsAt      *time.Time
+	EndsAt        *time.Time
+	InactiveURL   string
+	GeoRules      []GeoRule
+	DeviceRules   []DeviceRule
+	Variants      []Variant
+	Title         string
+	Tags          []string
+	Folder        string
+	Domain        string
+}
+
+// Revision registra un cambio de un enlace: el destino anterior y el nuevo,
+// la configuración resultante y quién hizo el cambio
+type Revision struct {
+	ID          uint
+	LinkCode    string
+	Action      string
+	OldURL      string
+	NewURL      string
+	Settings    LinkSettings
+	ActorType   string
+	ActorUserID *string
+	// Revisión restaurada, solo en las acciones restore
+	RestoredFromID *uint
+	CreatedAt      time.Time
+}
+
+// RevisionPage es una página del historial de un enlace
+type RevisionPage struct {
+	Items    []*Revision
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// Settings toma una copia de la configuración editable del enlace
+func (s *ShortLink) Settings() LinkSettings {
+	return LinkSettings{
+		OriginalURL:   s.OriginalURL,
+		ExpiresAt:     s.ExpiresAt,
+		PasswordHash:  s.PasswordHash,
+		MaxClicks:     s.MaxClicks,
+		RedirectType:  s.RedirectType,
+		ForwardQuery:  s.ForwardQuery,
+		QueryPriority: s.QueryPriority,
+		ForwardPath:   s.ForwardPath,
+		UTM:           s.UTM,
+		StartsAt:      s.StartsAt,
+		EndsAt:        s.EndsAt,
+		InactiveURL:   s.InactiveURL,
+		GeoRules:      s.GeoRules,
+		DeviceRules:   s.DeviceRules,
+		Variants:      s.Variants,
+		Title:         s.Title,
+		Tags:          s.Tags,
+		Folder:        s.Folder,
+		Domain:        s.Domain,
+	}
+}
+
+// ApplySettings reemplaza la configuración editable del enlace
+func (s *ShortLink) ApplySettings(settings LinkSettings) {
+	s.OriginalURL = settings.OriginalURL
+	s.ExpiresAt = settings.ExpiresAt
+	s.PasswordHash = settings.PasswordHash
+	s.MaxClicks = settings.MaxClicks
+	s.RedirectType = settings.RedirectType
+	s.ForwardQuery = settings.ForwardQuery
+	s.QueryPriority = settings.QueryPriority
+	s.ForwardPath = settings.ForwardPath
+	s.UTM = settings.UTM
+	s.StartsAt = settings.StartsAt
+	s.EndsAt = settings.EndsAt
+	s.InactiveURL = settings.InactiveURL
+	s.GeoRules = settings.GeoRules
+	s.DeviceRules = settings.DeviceRules
+	s.Variants = settings.Variants
+	s.Title = settings.Title
+	s.Tags = settings.Tags
+	s.Folder = settings.Folder
+	s.Domain = settings.Domain
+}
@@ -1,13 +1,130 @@
 package model
 
-import "time"
+import (
+	"net/http"
+	"time"
+)
+
+// Códigos de redirección permitidos por enlace
+const (
+	RedirectMovedPermanently  = http.StatusMovedPermanently  // 301
+	RedirectFound             = http.StatusFound             // 302 (por defecto)
+	RedirectTemporary         = http.StatusTemporaryRedirect // 307: conserva método y cuerpo
+	RedirectPermanentRedirect = http.StatusPermanentRedirect // 308: permanente, conserva método y cuerpo
+)
+
+// Qué lado gana cuando un parámetro llega en la visita y ya existe en el destino
+const (
+	QueryPriorityDestination = "destination"
+	QueryPriorityIncoming    = "incoming"
+)
 
 type ShortLink struct {
 	Code            string     `json:"code"`
 	OriginalURL     string     `json:"originalUrl"`
-	ManagementToken string    `json:"managementToken,omitempty"`
-	ExpiresAt       time.Time `json:"expiresAt,omitempty"`
+	ManagementToken string     `json:"managementToken,omitempty"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty"` // nil = no expira
 	CreatedAt       time.Time  `json:"createdAt"`
 	UpdatedAt       time.Time  `json:"updatedAt"`
-	UserID          *string     `json:"userId,omitempty"`
+	UserID          *string    `json:"userId,omitempty"`
+
+	// Hash bcrypt de la contraseña de acceso; vacío = enlace público
+	PasswordHash string `json:"-"`
+
+	// Límite de clicks (nil = ilimitado) y clicks ya consumidos del límite
+	MaxClicks      *int64 `json:"maxClicks,omitempty"`
+	ConsumedClicks int64  `json:"consumedClicks"`
+
+	// Ventana de activación: fuera de ella se usa InactiveURL (si existe)
+	StartsAt    *time.Time `json:"startsAt,omitempty"`
+	EndsAt      *time.Time `json:"endsAt,omitempty"`
+	InactiveURL string     `json:"inactiveUrl,omitempty"`
+
+	// Código HTTP usado al redirigir (301, 302, 307 o 308)
+	RedirectType int `json:"redirectType"`
+
+	// Reenvío de la query string y de la ruta adicional (/{code}/ruta) al destino
+	ForwardQuery  bool   `json:"forwardQuery"`
+	QueryPriority string `json:"queryPriority"`
+	ForwardPath   bool   `json:"forwardPath"`
+
+	// Parámetros UTM que se agregan al destino al redirigir
+	UTM UTM `json:"utm"`
+
+	// Un destino sospechoso deja el enlace en cuarentena: se muestra una
+	// advertencia antes de redirigir
+	Quarantined      bool   `json:"quarantined"`
+	QuarantineReason string `json:"quarantineReason,omitempty"`
+
+	// Estado asignado por un administrador (active, disabled o banned)
+	ModerationStatus string     `json:"moderationStatus"`
+	ModerationReason string     `json:"moderationReason,omitempty"`
+	ModeratedBy      *string    `json:"moderatedBy,omitempty"`
+	ModeratedAt      *time.Time `json:"moderatedAt,omitempty"`
+
+	// Reglas de destino por país; OriginalURL es el destino por defecto
+	GeoRules    []GeoRule    `json:"geoRules,omitempty"`
+	DeviceRules []DeviceRule `json:"deviceRules,omitempty"`
+	// Destinos en rotación A/B; si hay variantes reemplazan a OriginalURL
+	// como destino por defecto
+	Variants []Variant `json:"variants,omitempty"`
+
+	// Dominio personalizado verificado donde se publica el enlace; vacío =
+	// dominio compartido
+	Domain string `json:"domain,omitempty"`
+
+	// Organización de los enlaces de una cuenta
+	Title  string   `json:"title,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	Folder string   `json:"folder,omitempty"`
+
+	// Un enlace archivado deja de redirigir pero conserva sus estadísticas
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+	// Fecha en que se movió a la papelera (nil = no eliminado)
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// Calculado en consultas de listado, no se persiste
+	TotalClicks int64 `json:"totalClicks"`
+}
+
+func (s *ShortLink) IsExpired() bool {
+	return s.ExpiresAt != nil && time.Now().After(*s.ExpiresAt)
+}
+
+func (s *ShortLink) HasPassword() bool {
+	return s.PasswordHash != ""
+}
+
+// ClickLimitReached indica si el enlace ya agotó su límite de clicks
+func (s *ShortLink) ClickLimitReached() bool {
+	return s.MaxClicks != nil && s.ConsumedClicks >= *s.MaxClicks
+}
+
+// NotYetActive indica si la ventana de activación aún no empieza
+func (s *ShortLink) NotYetActive() bool {
+	return s.StartsAt != nil && time.Now().Before(*s.StartsAt)
+}
+
+// IsDisabled indica si un administrador deshabilitó o bloqueó el enlace
+func (s *ShortLink) IsDisabled() bool {
+	return s.ModerationStatus == ModerationDisabled || s.ModerationStatus == ModerationBanned
+}
+
+func (s *ShortLink) IsArchived() bool {
+	return s.ArchivedAt != nil
+}
+
+// IsPermanentRedirect indica si el enlace redirige con 301 o 308
+func (s *ShortLink) IsPermanentRedirect() bool {
+	return s.RedirectType == RedirectMovedPermanently || s.RedirectType == RedirectPermanentRedirect
+}
+
+// VariesByVisitor indica si el destino depende del visitante (reglas o rotación A/B)
+func (s *ShortLink) VariesByVisitor() bool {
+	return len(s.DeviceRules) > 0 || len(s.GeoRules) > 0 || len(s.Variants) > 0
+}
+
+// ActivationEnded indica si la ventana de activación ya terminó
+func (s *ShortLink) ActivationEnded() bool {
+	return s.EndsAt != nil && !time.Now().Before(*s.EndsAt)
 }
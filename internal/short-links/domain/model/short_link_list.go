@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// Campos de ordenamiento permitidos para el listado de enlaces
+const (
+	SortByCreatedAt = "createdAt"
+	SortByClicks    = "clicks"
+)
+
+// ListOptions define paginación y orden del listado de enlaces de un usuario
+type ListOptions struct {
+	Page     int
+	PageSize int
+	SortBy   string
+	SortDesc bool
+	Filter   ListFilter
+}
+
+// ListFilter restringe el listado; los campos vacíos no filtran
+type ListFilter struct {
+	// El enlace debe tener todas estas etiquetas
+	Tags   []string
+	Folder string
+	// Dominio del destino (no el dominio corto del enlace); incluye sus subdominios
+	DestinationHost string
+	// Dominio personalizado por el que se sirve el enlace
+	Domain string
+	// Rango de creación [CreatedFrom, CreatedTo)
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Búsqueda de texto completo sobre código, destino y título
+	Query string
+	// true lista solo los archivados; false, solo los que no lo están
+	Archived bool
+}
+
+// TagCount es una etiqueta de la cuenta y cuántos enlaces la usan
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// FolderCount es una carpeta de la cuenta y cuántos enlaces contiene
+type FolderCount struct {
+	Folder string `json:"folder"`
+	Count  int64  `json:"count"`
+}
+
+// ShortLinkPage es una página de resultados del listado
+type ShortLinkPage struct {
+	Items    []*ShortLink `json:"items"`
+	Total    int64        `json:"total"`
+	Page     int          `json:"page"`
+	PageSize int          `json:"pageSize"`
+}
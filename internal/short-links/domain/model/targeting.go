@@ -0,0 +1,66 @@
+package model
+
+// Plataformas reconocidas a partir del User-Agent
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+	PlatformWindows = "windows"
+	PlatformMacOS   = "macos"
+	PlatformLinux   = "linux"
+	PlatformOther   = "other"
+
+	// Grupos que se pueden usar en las reglas
+	PlatformMobile  = "mobile"
+	PlatformDesktop = "desktop"
+)
+
+// GeoRule envía a URL a los visitantes de alguno de los países indicados
+// (códigos ISO 3166-1 alpha-2). Las reglas se evalúan en orden
+type GeoRule struct {
+	Countries []string `json:"countries"`
+	URL       string   `json:"url"`
+}
+
+// DeviceRule envía a URL a los visitantes de alguna de las plataformas
+// indicadas. La URL puede ser un deep link (itms-apps://, market://, intent://)
+type DeviceRule struct {
+	Platforms []string `json:"platforms"`
+	URL       string   `json:"url"`
+}
+
+// Matches indica si la regla aplica a la plataforma del visitante,
+// considerando los grupos mobile y desktop
+func (r DeviceRule) Matches(platform string) bool {
+	for _, p := range r.Platforms {
+		switch {
+		case p == platform:
+			return true
+		case p == PlatformMobile && (platform == PlatformIOS || platform == PlatformAndroid):
+			return true
+		case p == PlatformDesktop && (platform == PlatformWindows || platform == PlatformMacOS || platform == PlatformLinux):
+			return true
+		}
+	}
+	return false
+}
+
+// Variant es uno de los destinos de un experimento A/B. Weight es el
+// porcentaje de clicks que recibe; los pesos de un enlace suman 100
+type Variant struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Weight int    `json:"weight"`
+}
+
+// Destination es la URL elegida para un visitante y, si salió de una
+// rotación A/B, el nombre de la variante servida
+type Destination struct {
+	URL     string
+	Variant string
+}
+
+// Visitor reúne los datos del visitante usados para elegir el destino
+type Visitor struct {
+	CountryCode string
+	Platform    string
+}
@@ -0,0 +1,30 @@
+package model
+
+import "net/url"
+
+// UTM son los parámetros de campaña guardados en el enlace. Se agregan al
+// destino al redirigir, sin modificar OriginalURL
+type UTM struct {
+	Source   string `json:"source,omitempty"`
+	Medium   string `json:"medium,omitempty"`
+	Campaign string `json:"campaign,omitempty"`
+	Term     string `json:"term,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+// Values devuelve los parámetros utm_* no vacíos listos para la query string
+func (u UTM) Values() url.Values {
+	values := url.Values{}
+	for key, value := range map[string]string{
+		"utm_source":   u.Source,
+		"utm_medium":   u.Medium,
+		"utm_campaign": u.Campaign,
+		"utm_term":     u.Term,
+		"utm_content":  u.Content,
+	} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"short-go/internal/short-links/domain/model"
+	"time"
+)
+
+type ReportRepository interface {
+	Create(report *model.Report) error
+	// HasOpenReport indica si la IP ya tiene un reporte abierto para el enlace
+	HasOpenReport(code string, reporterIP string) (bool, error)
+	List(opts model.ReportListOptions) ([]*model.Report, int64, error)
+	// CloseOpenReports cierra los reportes abiertos del enlace con el estado indicado
+	CloseOpenReports(code string, status string, resolvedBy string, at time.Time) (int64, error)
+}
@@ -1,10 +1,56 @@
 package repository
 
-import "short-go/internal/short-links/domain/model"
+import (
+	"errors"
+	"short-go/internal/short-links/domain/model"
+	"time"
+)
+
+// ErrDuplicateCode indica que el código o el token de gestión ya existen
+var ErrDuplicateCode = errors.New("el código del enlace ya existe")
 
 type ShortLinkRepository interface {
 	Create(shortLink *model.ShortLink) error
+	// Transaction ejecuta fn dentro de una transacción
+	Transaction(fn func(repo ShortLinkRepository) error) error
 	FindByCode(code string) (*model.ShortLink, error)
+	// CodeTaken indica si el código está en uso, incluidos los enlaces en la
+	// papelera y los archivados por el mantenimiento
+	CodeTaken(code string) (bool, error)
 	FindByManagementToken(token string) (*model.ShortLink, error)
+	// FindByCodes obtiene los enlaces existentes entre codes, en cualquier orden
+	FindByCodes(codes []string) ([]*model.ShortLink, error)
+	FindByUserID(userID string, opts model.ListOptions) ([]*model.ShortLink, int64, error)
+	ListTags(userID string) ([]model.TagCount, error)
+	ListFolders(userID string) ([]model.FolderCount, error)
+	Update(shortLink *model.ShortLink) error
+	// AssignOwner asigna el enlace al usuario solo si aún es anónimo
+	AssignOwner(code string, userID string) (bool, error)
+	// DeleteByCode mueve el enlace a la papelera; el código sigue ocupado
 	DeleteByCode(code string) error
+	// Papelera: enlaces eliminados después de since
+	FindDeletedByCode(code string) (*model.ShortLink, error)
+	FindDeletedByUserID(userID string, since time.Time, page, pageSize int) ([]*model.ShortLink, int64, error)
+	RestoreDeleted(code string) (bool, error)
+	// SetArchived archiva (at) o desarchiva (nil) el enlace
+	SetArchived(code string, at *time.Time) (bool, error)
+	// DetachDomain quita el dominio personalizado host de los enlaces del usuario
+	DetachDomain(userID string, host string) (int64, error)
+	// ConsumeClick incrementa consumed_clicks solo si no supera max_clicks
+	ConsumeClick(code string) (bool, error)
+	// Historial de cambios del enlace, de la revisión más reciente a la más antigua
+	CreateRevision(revision *model.Revision) error
+	FindRevisions(code string, page, pageSize int) ([]*model.Revision, int64, error)
+	FindRevision(code string, id uint) (*model.Revision, error)
+	HasRevisions(code string) (bool, error)
+	// SetModeration guarda el estado de moderación; false si el enlace no existe
+	SetModeration(code string, moderation model.Moderation) (bool, error)
+
+	// Mantenimiento: eliminan o archivan los enlaces expirados antes de cutoff.
+	// Ninguno de los tres retira enlaces bloqueados, cuyo código queda reservado
+	PurgeExpired(cutoff time.Time) (int64, error)
+	ArchiveExpired(cutoff time.Time) (int64, error)
+	// PurgeDeleted elimina definitivamente los enlaces que están en la papelera
+	// desde antes de cutoff, junto con sus clicks, revisiones y reportes
+	PurgeDeleted(cutoff time.Time) (int64, error)
 }
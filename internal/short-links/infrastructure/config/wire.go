@@ -1,12 +1,16 @@
 package config
 
 import (
+	"log"
 	"short-go/config"
 	analyticsService "short-go/internal/analytics/application/service"
+	domainRepository "short-go/internal/domains/domain/repository"
+	"short-go/internal/shared/geoip"
 	"short-go/internal/shared/infrastructure/middleware"
 	"short-go/internal/short-links/application/service"
 	"short-go/internal/short-links/infrastructure/http/handler"
 	gormRepo "short-go/internal/short-links/infrastructure/persistence/gorm"
+	"short-go/internal/short-links/infrastructure/safety"
 
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
@@ -16,26 +20,115 @@ type ShortenerModule struct {
 	Handler *handler.ShortLinkHandler
 }
 
-func NewShortenerModule(db *gorm.DB, cfg *config.Config, analyticsService *analyticsService.AnalyticsService) *ShortenerModule {
+func NewShortenerModule(
+	db *gorm.DB,
+	cfg *config.Config,
+	analyticsService *analyticsService.AnalyticsService,
+	geoResolver geoip.Resolver,
+	domainRepo domainRepository.DomainRepository,
+) *ShortenerModule {
 	// Repositories
 	shortLinkRepo := gormRepo.NewShortLinkRepository(db)
+	reportRepo := gormRepo.NewReportRepository(db)
 
 	// Services
-	shortLinkService := service.NewShortLinkService(shortLinkRepo)
+	expirationPolicy := service.ExpirationPolicy{
+		AnonymousDefault: cfg.AnonymousLinkDefaultTTL,
+		AnonymousMax:     cfg.AnonymousLinkMaxTTL,
+		UserDefault:      cfg.UserLinkDefaultTTL,
+		UserMax:          cfg.UserLinkMaxTTL,
+	}
+	urlValidator := service.NewURLValidator(service.URLPolicy{
+		AllowedSchemes:  cfg.AllowedURLSchemes,
+		DeepLinkSchemes: cfg.DeepLinkSchemes,
+		MaxLength:       cfg.MaxURLLength,
+		OwnHosts:        []string{cfg.Domain},
+		CustomHosts:     domainRepo,
+	})
+	shortLinkService := service.NewShortLinkService(
+		shortLinkRepo,
+		service.NewRandomCodeGenerator(),
+		expirationPolicy,
+		urlValidator,
+		newSafetyChecker(cfg),
+		domainRepo,
+		cfg.TrashRetention,
+	)
+	moderationService := service.NewModerationService(shortLinkRepo, reportRepo)
 
 	// Handlers
-	shortLinkHandler := handler.NewShortLinkHandler(shortLinkService, analyticsService, cfg)
+	shortLinkHandler := handler.NewShortLinkHandler(shortLinkService, moderationService, analyticsService, geoResolver, cfg)
 
 	return &ShortenerModule{
 		Handler: shortLinkHandler,
 	}
 }
 
+// newSafetyChecker arma los checkers de seguridad de destinos. Un servicio de
+// reputación externo se agrega a la lista implementando service.SafetyChecker
+func newSafetyChecker(cfg *config.Config) service.SafetyChecker {
+	checkers := service.MultiSafetyChecker{}
+
+	if len(cfg.SafetyBlocklistFiles) > 0 {
+		verdict := service.SafetyMalicious
+		if cfg.SafetyBlocklistAction == "quarantine" {
+			verdict = service.SafetySuspicious
+		}
+
+		// Una lista mal configurada no debe desactivar la protección en silencio
+		blocklist, err := safety.NewBlocklistChecker(cfg.SafetyBlocklistFiles, verdict)
+		if err != nil {
+			log.Fatal("Error cargando listas de bloqueo:", err)
+		}
+		log.Printf("Listas de bloqueo cargadas: %d entradas", blocklist.Size())
+		checkers = append(checkers, blocklist)
+	}
+
+	return checkers
+}
+
 // RegisterRoutes registra las rutas del módulo shortener
 func (m *ShortenerModule) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
     r.Route("/api/short-links", func(r chi.Router) {
 		r.With(authMiddleware.OptionalAuth).Post("/", m.Handler.CreateShortLink)
+
+		r.With(authMiddleware.RequireAuth).Get("/", m.Handler.ListShortLinks)
+		r.With(authMiddleware.RequireAuth).Post("/claim", m.Handler.ClaimShortLinks)
+		r.With(authMiddleware.RequireAuth).Post("/bulk", m.Handler.CreateShortLinksBulk)
+		r.With(authMiddleware.RequireAuth).Get("/tags", m.Handler.ListTags)
+		r.With(authMiddleware.RequireAuth).Get("/folders", m.Handler.ListFolders)
+		r.With(authMiddleware.RequireAuth).Get("/trash", m.Handler.ListTrash)
+
+		// Gestión de un enlace: JWT del dueño o header X-Management-Token
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.OptionalAuth)
+			r.Get("/{code}", m.Handler.GetShortLink)
+			r.Patch("/{code}", m.Handler.UpdateShortLink)
+			r.Delete("/{code}", m.Handler.DeleteShortLink)
+			r.Put("/{code}/tags", m.Handler.AssignTags)
+			r.Put("/{code}/folder", m.Handler.MoveToFolder)
+			r.Get("/{code}/revisions", m.Handler.ListRevisions)
+			r.Post("/{code}/revisions/{revisionId}/restore", m.Handler.RestoreRevision)
+			r.Post("/{code}/report", m.Handler.ReportShortLink)
+			r.Post("/{code}/archive", m.Handler.ArchiveShortLink)
+			r.Post("/{code}/unarchive", m.Handler.UnarchiveShortLink)
+			r.Post("/trash/{code}/restore", m.Handler.RestoreDeletedShortLink)
+		})
+	})
+
+	// Moderación: solo cuentas con rol admin
+	r.Route("/api/admin", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAdmin)
+		r.Get("/reports", m.Handler.ListReports)
+		r.Post("/short-links/{code}/disable", m.Handler.DisableShortLink)
+		r.Post("/short-links/{code}/ban", m.Handler.BanShortLink)
+		r.Post("/short-links/{code}/restore", m.Handler.RestoreShortLink)
 	})
 
     r.Get("/{code}", m.Handler.Redirect)
+    r.Post("/{code}", m.Handler.Unlock)
+
+	// Reenvío de rutas: /{code}/docs/page -> destino/docs/page
+	r.Get("/{code}/*", m.Handler.Redirect)
+	r.Post("/{code}/*", m.Handler.Unlock)
 }
\ No newline at end of file
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	sharedContext "short-go/internal/shared/context"
+	sharedhttp "short-go/internal/shared/http"
+	format "short-go/internal/shared/http/utils"
+	"short-go/internal/shared/timeutil"
+	"short-go/internal/short-links/application/service"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxBulkBodySize limita el tamaño del JSON o del CSV subido
+const maxBulkBodySize = 10 << 20
+
+// BulkLinkRequest es una fila del JSON de creación masiva
+type BulkLinkRequest struct {
+	URL    string   `json:"url" validate:"required"`
+	Alias  string   `json:"alias,omitempty"`
+	Title  string   `json:"title,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	Folder string   `json:"folder,omitempty"`
+	ExpirationRequest
+}
+
+type BulkRowResponse struct {
+	Row         int    `json:"row"`
+	Code        string `json:"code,omitempty"`
+	ShortUrl    string `json:"shortUrl,omitempty"`
+	OriginalUrl string `json:"originalUrl,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+type BulkCreateResponse struct {
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Results []BulkRowResponse `json:"results"`
+}
+
+// CreateShortLinksBulk - POST /api/short-links/bulk
+// Acepta un arreglo JSON, un CSV en el cuerpo (text/csv) o un CSV subido en
+// el campo "file" de un formulario multipart
+func (h *ShortLinkHandler) CreateShortLinksBulk(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+	r.Body = http.MaxBytesReader(w, r.Body, maxBulkBodySize)
+
+	var rows []service.BulkLinkInput
+	var failures []service.BulkRowResult
+	var err error
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		file, _, fileErr := r.FormFile("file")
+		if fileErr != nil {
+			sharedhttp.ErrorResponse(w, http.StatusBadRequest, "Adjunta el CSV en el campo 'file'")
+			return
+		}
+		defer file.Close()
+		rows, failures, err = parseBulkCSV(file)
+	case "text/csv":
+		rows, failures, err = parseBulkCSV(r.Body)
+	default:
+		rows, failures, err = h.parseBulkJSON(r.Body)
+	}
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := &service.BulkResult{}
+	if len(rows) > 0 || len(failures) == 0 {
+		result, err = h.shortLinkService.CreateShortLinksBulk(userID, rows)
+		if err != nil {
+			h.manageErrorResponse(w, err)
+			return
+		}
+	}
+
+	results := append(result.Results, failures...)
+	sort.Slice(results, func(i, j int) bool { return results[i].Row < results[j].Row })
+
+	resp := BulkCreateResponse{
+		Created: result.Created,
+		Failed:  result.Failed + len(failures),
+		Results: make([]BulkRowResponse, len(results)),
+	}
+	for i, rowResult := range results {
+		resp.Results[i] = h.toBulkRowResponse(rowResult)
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, resp)
+}
+
+// parseBulkJSON lee un arreglo de BulkLinkRequest. Las filas inválidas se
+// reportan como fallidas sin detener la lectura
+func (h *ShortLinkHandler) parseBulkJSON(body io.Reader) ([]service.BulkLinkInput, []service.BulkRowResult, error) {
+	var items []BulkLinkRequest
+	if err := json.NewDecoder(body).Decode(&items); err != nil {
+		return nil, nil, errors.New("JSON inválido: se espera un arreglo de enlaces")
+	}
+	if len(items) > service.MaxBulkRows {
+		return nil, nil, service.ErrBulkTooManyRows
+	}
+
+	var rows []service.BulkLinkInput
+	var failures []service.BulkRowResult
+	for i, item := range items {
+		row := i + 1
+		if err := h.validator.Struct(&item); err != nil {
+			failures = append(failures, service.BulkRowResult{Row: row, Err: errors.New(format.FormatValidationError(err))})
+			continue
+		}
+		expiration, err := item.ExpirationRequest.toInput()
+		if err != nil {
+			failures = append(failures, service.BulkRowResult{Row: row, Err: err})
+			continue
+		}
+		rows = append(rows, service.BulkLinkInput{
+			Row:        row,
+			URL:        item.URL,
+			Alias:      item.Alias,
+			Expiration: expiration,
+			Title:      item.Title,
+			Tags:       item.Tags,
+			Folder:     item.Folder,
+		})
+	}
+
+	return rows, failures, nil
+}
+
+// parseBulkCSV lee un CSV con encabezado. La columna url es obligatoria;
+// alias, title, expires, tags y folder son opcionales y el resto se ignora. Las filas se
+// numeran desde 1 sin contar el encabezado
+func parseBulkCSV(body io.Reader) ([]service.BulkLinkInput, []service.BulkRowResult, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.New("CSV inválido: falta el encabezado")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Excel agrega un BOM al inicio del archivo
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["url"]; !ok {
+		return nil, nil, errors.New("CSV inválido: falta la columna 'url'")
+	}
+
+	cell := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []service.BulkLinkInput
+	var failures []service.BulkRowResult
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if row > service.MaxBulkRows {
+			return nil, nil, service.ErrBulkTooManyRows
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, nil, fmt.Errorf("no se pudo leer el CSV: %w", err)
+			}
+			failures = append(failures, service.BulkRowResult{Row: row, Err: errors.New("fila con formato CSV inválido")})
+			continue
+		}
+
+		expiration, err := parseCSVExpiration(cell(record, "expires"))
+		if err != nil {
+			failures = append(failures, service.BulkRowResult{Row: row, Err: err})
+			continue
+		}
+
+		rows = append(rows, service.BulkLinkInput{
+			Row:        row,
+			URL:        cell(record, "url"),
+			Alias:      cell(record, "alias"),
+			Expiration: expiration,
+			Title:      cell(record, "title"),
+			Tags:       splitCSVTags(cell(record, "tags")),
+			Folder:     cell(record, "folder"),
+		})
+	}
+
+	return rows, failures, nil
+}
+
+// parseCSVExpiration acepta "never", una fecha (RFC3339 o AAAA-MM-DD) o una
+// duración relativa ("30d"). Vacío usa la expiración por defecto
+func parseCSVExpiration(value string) (service.ExpirationInput, error) {
+	if value == "" {
+		return service.ExpirationInput{}, nil
+	}
+	if strings.EqualFold(value, "never") {
+		return service.ExpirationInput{NeverExpires: true}, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if expiresAt, err := time.Parse(layout, value); err == nil {
+			return service.ExpirationInput{ExpiresAt: &expiresAt}, nil
+		}
+	}
+	if expiresIn, err := timeutil.ParseDuration(value); err == nil && expiresIn > 0 {
+		return service.ExpirationInput{ExpiresIn: expiresIn}, nil
+	}
+	return service.ExpirationInput{}, errors.New("expiración inválida: usa una fecha, una duración como '30d' o 'never'")
+}
+
+// splitCSVTags separa las etiquetas de una celda por comas o punto y coma
+func splitCSVTags(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' })
+}
+
+// toBulkRowResponse construye el resultado público de una fila
+func (h *ShortLinkHandler) toBulkRowResponse(result service.BulkRowResult) BulkRowResponse {
+	if result.Err != nil {
+		message := result.Err.Error()
+		// Los errores de generación envuelven la causa interna, que no se expone
+		if errors.Is(result.Err, service.ErrCodeGeneration) {
+			message = service.ErrCodeGeneration.Error()
+		}
+		return BulkRowResponse{Row: result.Row, Error: message}
+	}
+
+	return BulkRowResponse{
+		Row:         result.Row,
+		Code:        result.ShortLink.Code,
+		ShortUrl:    fmt.Sprintf("%s/%s", sharedhttp.ShortURLBase(h.config, result.ShortLink.Domain), result.ShortLink.Code),
+		OriginalUrl: result.ShortLink.OriginalURL,
+	}
+}
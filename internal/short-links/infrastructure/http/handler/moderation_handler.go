@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	sharedContext "short-go/internal/shared/context"
+	sharedhttp "short-go/internal/shared/http"
+	format "short-go/internal/shared/http/utils"
+	"short-go/internal/short-links/application/service"
+	"short-go/internal/short-links/domain/model"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReportShortLinkRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=phishing malware spam inappropriate other"`
+	Details string `json:"details" validate:"max=1000"`
+}
+
+type ModerationRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// ReportShortLink - POST /api/short-links/{code}/report
+func (h *ShortLinkHandler) ReportShortLink(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	var req ReportShortLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	ip := sharedhttp.ClientIP(r)
+	var reporterUserID *string
+	if userID := sharedContext.GetUserID(r.Context()); userID != "" {
+		reporterUserID = &userID
+	}
+
+	// Límite por IP y, con sesión, también por cuenta
+	allowed := h.reportLimiter.Allow("ip:" + ip)
+	if reporterUserID != nil {
+		allowed = h.reportLimiter.Allow("user:"+*reporterUserID) && allowed
+	}
+	if !allowed {
+		sharedhttp.ErrorResponse(w, http.StatusTooManyRequests, "Demasiados reportes, vuelve a intentarlo más tarde")
+		return
+	}
+
+	err := h.moderationService.ReportShortLink(service.ReportShortLinkInput{
+		Code:           code,
+		Reason:         req.Reason,
+		Details:        req.Details,
+		ReporterIP:     ip,
+		ReporterUserID: reporterUserID,
+	})
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	// La misma respuesta para reportes nuevos y repetidos
+	sharedhttp.SuccessResponse(w, http.StatusAccepted, map[string]string{"message": "Reporte recibido, gracias por avisarnos"})
+}
+
+// ListReports - GET /api/admin/reports
+func (h *ShortLinkHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	opts := model.ReportListOptions{Status: query.Get("status")}
+
+	var err error
+	if opts.Page, opts.PageSize, err = sharedhttp.ParsePagination(r); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.moderationService.ListReports(opts)
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, page)
+}
+
+// DisableShortLink - POST /api/admin/short-links/{code}/disable
+func (h *ShortLinkHandler) DisableShortLink(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, h.moderationService.DisableShortLink)
+}
+
+// BanShortLink - POST /api/admin/short-links/{code}/ban
+func (h *ShortLinkHandler) BanShortLink(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, h.moderationService.BanShortLink)
+}
+
+// RestoreShortLink - POST /api/admin/short-links/{code}/restore
+func (h *ShortLinkHandler) RestoreShortLink(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, h.moderationService.RestoreShortLink)
+}
+
+// moderate aplica una acción de moderación; el cuerpo es opcional al restaurar
+func (h *ShortLinkHandler) moderate(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(code, reason, adminID string) (*model.ShortLink, error),
+) {
+	code := chi.URLParam(r, "code")
+	adminID := sharedContext.GetUserID(r.Context())
+
+	var req ModerationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	shortLink, err := action(code, req.Reason, adminID)
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, h.toResponse(shortLink))
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	sharedContext "short-go/internal/shared/context"
+	sharedhttp "short-go/internal/shared/http"
+	"short-go/internal/short-links/application/service"
+	"short-go/internal/short-links/domain/model"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AssignTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type MoveToFolderRequest struct {
+	// "" saca el enlace de su carpeta
+	Folder string `json:"folder"`
+}
+
+// AssignTags - PUT /api/short-links/{code}/tags
+// Reemplaza las etiquetas del enlace; [] las elimina
+func (h *ShortLinkHandler) AssignTags(w http.ResponseWriter, r *http.Request) {
+	var req AssignTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	tags := req.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	h.organize(w, r, service.UpdateShortLinkInput{Tags: &tags})
+}
+
+// MoveToFolder - PUT /api/short-links/{code}/folder
+func (h *ShortLinkHandler) MoveToFolder(w http.ResponseWriter, r *http.Request) {
+	var req MoveToFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	h.organize(w, r, service.UpdateShortLinkInput{Folder: &req.Folder})
+}
+
+// ListTags - GET /api/short-links/tags
+func (h *ShortLinkHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.shortLinkService.ListUserTags(sharedContext.GetUserID(r.Context()))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener las etiquetas")
+		return
+	}
+	if tags == nil {
+		tags = []model.TagCount{}
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, tags)
+}
+
+// ListFolders - GET /api/short-links/folders
+func (h *ShortLinkHandler) ListFolders(w http.ResponseWriter, r *http.Request) {
+	folders, err := h.shortLinkService.ListUserFolders(sharedContext.GetUserID(r.Context()))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener las carpetas")
+		return
+	}
+	if folders == nil {
+		folders = []model.FolderCount{}
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, folders)
+}
+
+// organize aplica una edición parcial con las mismas reglas de acceso que PATCH
+func (h *ShortLinkHandler) organize(w http.ResponseWriter, r *http.Request, input service.UpdateShortLinkInput) {
+	code := chi.URLParam(r, "code")
+
+	shortLink, err := h.shortLinkService.UpdateShortLink(code, h.linkAccess(r), input)
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, h.toResponse(shortLink))
+}
+
+// parseListFilter lee los filtros del listado: tag (repetible), folder,
+// destinationHost, domain, from/to (RFC3339 o AAAA-MM-DD), q y archived
+func parseListFilter(query url.Values) (model.ListFilter, error) {
+	filter := model.ListFilter{
+		Tags:            query["tag"],
+		Folder:          query.Get("folder"),
+		DestinationHost: query.Get("destinationHost"),
+		Domain:          query.Get("domain"),
+		Query:           query.Get("q"),
+	}
+
+	if raw := query.Get("from"); raw != "" {
+		from, _, err := parseFilterDate(raw)
+		if err != nil {
+			return filter, errors.New("Parámetro 'from' inválido: usa RFC3339 o AAAA-MM-DD")
+		}
+		filter.CreatedFrom = &from
+	}
+
+	if raw := query.Get("to"); raw != "" {
+		to, dateOnly, err := parseFilterDate(raw)
+		if err != nil {
+			return filter, errors.New("Parámetro 'to' inválido: usa RFC3339 o AAAA-MM-DD")
+		}
+		// Una fecha sin hora incluye el día completo
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.CreatedTo = &to
+	}
+
+	switch query.Get("archived") {
+	case "", "false":
+	case "true":
+		filter.Archived = true
+	default:
+		return filter, errors.New("Parámetro 'archived' inválido: usa 'true' o 'false'")
+	}
+
+	return filter, nil
+}
+
+// parseFilterDate acepta RFC3339 o una fecha AAAA-MM-DD (UTC)
+func parseFilterDate(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	return t, true, err
+}
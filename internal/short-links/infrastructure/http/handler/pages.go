@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+	sharedhttp "short-go/internal/shared/http"
+)
+
+// quarantinePageTemplate advierte antes de seguir un enlace en cuarentena.
+// El botón vuelve a la misma URL con el parámetro de confirmación
+var quarantinePageTemplate = template.Must(template.New("quarantine").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Advertencia · ShortGo</title>
+<style>
+` + sharedhttp.PageStyle + `main{border-top:6px solid #e67e22}
+.destination{word-break:break-all;font-family:monospace;background:#f5f5f7;padding:.5rem;border-radius:6px}
+a.button{display:inline-block;margin-top:1rem;padding:.6rem 1.4rem;border-radius:8px;background:#c0392b;color:#fff;text-decoration:none}
+</style>
+</head>
+<body>
+<main>
+<h1>Este enlace podría no ser seguro</h1>
+<p>El destino fue marcado como sospechoso. Podría intentar robar tus datos o contraseñas.</p>
+<p class="destination">{{.Destination}}</p>
+<p>Si confías en este sitio puedes continuar bajo tu propio riesgo.</p>
+<a class="button" href="{{.ContinueURL}}" rel="nofollow noreferrer">Continuar de todos modos</a>
+</main>
+</body>
+</html>`))
+
+type quarantinePage struct {
+	Destination string
+	ContinueURL string
+}
+
+// renderQuarantinePage responde con la advertencia de un enlace en cuarentena
+func renderQuarantinePage(w http.ResponseWriter, destination, continueURL string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	quarantinePageTemplate.Execute(w, quarantinePage{Destination: destination, ContinueURL: continueURL})
+}
+
+// unlockPageTemplate pide la contraseña de un enlace protegido.
+// El formulario se envía por POST a la misma URL
+var unlockPageTemplate = template.Must(template.New("unlock").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Enlace protegido · ShortGo</title>
+<style>
+` + sharedhttp.PageStyle + `input{width:100%;box-sizing:border-box;padding:.6rem;margin:.5rem 0 1rem;border:1px solid #ccc;border-radius:8px;font-size:1rem}
+button{padding:.6rem 1.4rem;border:0;border-radius:8px;background:#1d1d1f;color:#fff;font-size:1rem;cursor:pointer}
+.error{color:#c0392b}
+</style>
+</head>
+<body>
+<main>
+<h1>Enlace protegido</h1>
+<p>Ingresa la contraseña para continuar.</p>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<form method="post">
+<input type="password" name="password" required autofocus autocomplete="current-password">
+<button type="submit">Continuar</button>
+</form>
+</main>
+</body>
+</html>`))
+
+type unlockPage struct {
+	Error string
+}
+
+// renderUnlockPage responde con el formulario de contraseña
+func renderUnlockPage(w http.ResponseWriter, status int, errorMessage string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	unlockPageTemplate.Execute(w, unlockPage{Error: errorMessage})
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Vigencia del enlace "Continuar" de la advertencia de cuarentena
+const quarantineConfirmTTL = 10 * time.Minute
+
+// quarantineConfirmSigner emite y valida el token con el que la página de
+// advertencia confirma que el visitante decidió continuar. La firma incluye
+// el código y la IP del visitante, así que un enlace de confirmación
+// compartido por el creador no salta la advertencia a otros visitantes
+type quarantineConfirmSigner struct {
+	secret []byte
+}
+
+func newQuarantineConfirmSigner(secret string) *quarantineConfirmSigner {
+	return &quarantineConfirmSigner{secret: []byte(secret)}
+}
+
+// token genera el valor del parámetro de confirmación: <expiraUnix>.<firma>
+func (s *quarantineConfirmSigner) token(code, ip string) string {
+	expiresUnix := strconv.FormatInt(time.Now().Add(quarantineConfirmTTL).Unix(), 10)
+	return expiresUnix + "." + s.sign(code, ip, expiresUnix)
+}
+
+// verify indica si el token es válido y vigente para el enlace y la IP
+func (s *quarantineConfirmSigner) verify(token, code, ip string) bool {
+	expiresUnix, signature, found := strings.Cut(token, ".")
+	if !found {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(expiresUnix, 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(s.sign(code, ip, expiresUnix)))
+}
+
+func (s *quarantineConfirmSigner) sign(code, ip, expiresUnix string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("quarantine|" + code + "|" + ip + "|" + expiresUnix))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
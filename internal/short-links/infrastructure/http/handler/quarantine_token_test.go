@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestQuarantineConfirmSigner(t *testing.T) {
+	signer := newQuarantineConfirmSigner("secreto")
+	token := signer.token("abc123", "203.0.113.7")
+
+	expiredUnix := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	expired := expiredUnix + "." + signer.sign("abc123", "203.0.113.7", expiredUnix)
+
+	tests := []struct {
+		name   string
+		signer *quarantineConfirmSigner
+		token  string
+		code   string
+		ip     string
+		want   bool
+	}{
+		{name: "mismo enlace e IP", signer: signer, token: token, code: "abc123", ip: "203.0.113.7", want: true},
+		{name: "otro enlace", signer: signer, token: token, code: "xyz789", ip: "203.0.113.7"},
+		{name: "otra IP", signer: signer, token: token, code: "abc123", ip: "198.51.100.1"},
+		{name: "otro secreto", signer: newQuarantineConfirmSigner("otro"), token: token, code: "abc123", ip: "203.0.113.7"},
+		{name: "vencido", signer: signer, token: expired, code: "abc123", ip: "203.0.113.7"},
+		{name: "firma alterada", signer: signer, token: token + "x", code: "abc123", ip: "203.0.113.7"},
+		{name: "sin separador", signer: signer, token: "1", code: "abc123", ip: "203.0.113.7"},
+		{name: "expiración no numérica", signer: signer, token: "mañana.firma", code: "abc123", ip: "203.0.113.7"},
+		{name: "vacío", signer: signer, token: "", code: "abc123", ip: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.signer.verify(tt.token, tt.code, tt.ip); got != tt.want {
+				t.Errorf("verify = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
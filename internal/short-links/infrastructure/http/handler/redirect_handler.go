@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	analyticsModel "short-go/internal/analytics/domain/model"
+	sharedhttp "short-go/internal/shared/http"
+	"short-go/internal/short-links/application/service"
+	"short-go/internal/short-links/domain/model"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Parámetro con el que la advertencia de cuarentena confirma que el visitante
+// quiere continuar; lleva un token firmado y no se reenvía al destino
+const quarantineConfirmParam = "sg_confirm"
+
+// Redirect - GET /{code} y GET /{code}/*
+func (h *ShortLinkHandler) Redirect(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	shortLink, fallback, ok := h.resolveRedirect(w, r, code)
+	if !ok {
+		return
+	}
+
+	// Las rutas adicionales solo existen si el enlace las reenvía
+	extraPath := chi.URLParam(r, "*")
+	if extraPath != "" && !shortLink.ForwardPath {
+		sharedhttp.ErrorResponse(w, http.StatusNotFound, "Enlace no encontrado")
+		return
+	}
+
+	// Los enlaces con contraseña exigen un desbloqueo previo
+	if shortLink.HasPassword() && !h.unlockSigner.verify(r, shortLink) {
+		renderUnlockPage(w, http.StatusOK, "")
+		return
+	}
+
+	ip := sharedhttp.ClientIP(r)
+	query := r.URL.Query()
+	confirmToken := query.Get(quarantineConfirmParam)
+	query.Del(quarantineConfirmParam)
+
+	// Fuera de la ventana de activación se va al destino alternativo, que
+	// también pasa por la advertencia de cuarentena y no cuenta el click
+	if fallback != "" {
+		if !h.confirmQuarantine(w, r, shortLink, fallback, confirmToken, ip) {
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		http.Redirect(w, r, fallback, http.StatusFound)
+		return
+	}
+
+	// Metadatos del visitante; el país se resuelve con la base local
+	// para elegir el destino sin esperar a una API remota
+	visitor := model.Visitor{
+		CountryCode: h.geoResolver.CountryCode(ip),
+		Platform:    platformFromUserAgent(r.UserAgent()),
+	}
+
+	destination := h.shortLinkService.ResolveDestination(shortLink, visitor)
+	target := h.shortLinkService.ApplyPassthrough(shortLink, destination.URL, query, extraPath)
+
+	// Los enlaces en cuarentena solo cuentan el click si el visitante decide
+	// continuar desde la advertencia
+	if !h.confirmQuarantine(w, r, shortLink, target, confirmToken, ip) {
+		return
+	}
+
+	click := &analyticsModel.Click{
+		LinkCode:    code,
+		IPAddress:   ip,
+		UserAgent:   r.UserAgent(),
+		Referrer:    r.Referer(),
+		CountryCode: visitor.CountryCode,
+		Variant:     destination.Variant,
+	}
+	setClickUTM(click, target)
+
+	if shortLink.MaxClicks != nil {
+		// El click se descuenta después del desbloqueo para no gastar el límite
+		// en visitas que no llegan al destino
+		if err := h.shortLinkService.ConsumeClick(shortLink); err != nil {
+			if err == service.ErrClickLimitReached {
+				renderClickLimitPage(w)
+				return
+			}
+			sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al procesar el enlace")
+			return
+		}
+		h.analyticsService.TrackGuaranteedClick(click)
+	} else {
+		h.analyticsService.TrackClick(click)
+	}
+
+	h.setRedirectCacheHeaders(w, shortLink)
+
+	http.Redirect(w, r, target, shortLink.RedirectType)
+}
+
+// Unlock - POST /{code} y POST /{code}/*
+// Verifica la contraseña del formulario y, si es correcta, guarda una cookie
+// firmada y vuelve a la URL del enlace para registrar el click y redirigir
+func (h *ShortLinkHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	shortLink, _, ok := h.resolveRedirect(w, r, code)
+	if !ok {
+		return
+	}
+
+	if shortLink.HasPassword() {
+		// Los intentos se limitan por enlace e IP para frenar la fuerza bruta
+		if !h.unlockLimiter.Allow(shortLink.Code + "|" + sharedhttp.ClientIP(r)) {
+			renderUnlockPage(w, http.StatusTooManyRequests, "Demasiados intentos. Vuelve a intentarlo más tarde")
+			return
+		}
+		if !h.shortLinkService.VerifyLinkPassword(shortLink, r.PostFormValue("password")) {
+			renderUnlockPage(w, http.StatusUnauthorized, "Contraseña incorrecta")
+			return
+		}
+		h.unlockSigner.set(w, shortLink)
+	}
+
+	// 303 convierte el POST en un GET a la misma URL
+	http.Redirect(w, r, r.URL.RequestURI(), http.StatusSeeOther)
+}
+
+// confirmQuarantine muestra la advertencia de un enlace en cuarentena hasta
+// que el visitante la confirma; false si ya respondió con la advertencia
+func (h *ShortLinkHandler) confirmQuarantine(w http.ResponseWriter, r *http.Request, shortLink *model.ShortLink, target, confirmToken, ip string) bool {
+	if !shortLink.Quarantined || h.quarantineSigner.verify(confirmToken, shortLink.Code, ip) {
+		return true
+	}
+
+	continueURL := *r.URL
+	continueQuery := r.URL.Query()
+	continueQuery.Set(quarantineConfirmParam, h.quarantineSigner.token(shortLink.Code, ip))
+	continueURL.RawQuery = continueQuery.Encode()
+	renderQuarantinePage(w, target, continueURL.RequestURI())
+	return false
+}
+
+// resolveRedirect obtiene el enlace o responde con la página de error. Fuera
+// de la ventana de activación devuelve además el destino alternativo, para
+// que pase por el desbloqueo y la advertencia como el destino normal
+func (h *ShortLinkHandler) resolveRedirect(w http.ResponseWriter, r *http.Request, code string) (*model.ShortLink, string, bool) {
+	shortLink, err := h.shortLinkService.ResolveRedirect(h.requestHost(r), code)
+	if err != nil {
+		switch err {
+		case service.ErrShortLinkDisabled:
+			// Un enlace bloqueado no volverá; uno deshabilitado puede restaurarse
+			status := http.StatusForbidden
+			if shortLink.ModerationStatus == model.ModerationBanned {
+				status = http.StatusGone
+			}
+			sharedhttp.RenderMessagePage(w, status, "Enlace deshabilitado",
+				"Este enlace fue deshabilitado por infringir las condiciones de uso.")
+			return nil, "", false
+		case service.ErrShortLinkArchived:
+			sharedhttp.RenderMessagePage(w, http.StatusGone, "Enlace archivado",
+				"Este enlace fue archivado por su dueño y ya no redirige.")
+			return nil, "", false
+		case service.ErrShortLinkExpired:
+			sharedhttp.RenderMessagePage(w, http.StatusGone, "Enlace expirado",
+				"Este enlace ya no está disponible porque alcanzó su fecha de expiración.")
+			return nil, "", false
+		case service.ErrClickLimitReached:
+			renderClickLimitPage(w)
+			return nil, "", false
+		case service.ErrShortLinkNotYetActive, service.ErrShortLinkEnded:
+			if shortLink.InactiveURL != "" {
+				return shortLink, shortLink.InactiveURL, true
+			}
+			if err == service.ErrShortLinkNotYetActive {
+				sharedhttp.RenderMessagePage(w, http.StatusForbidden, "Enlace aún no disponible",
+					"Este enlace todavía no está activo. Vuelve a intentarlo más tarde.")
+			} else {
+				sharedhttp.RenderMessagePage(w, http.StatusGone, "Campaña finalizada",
+					"Este enlace ya no está activo porque su campaña terminó.")
+			}
+			return nil, "", false
+		}
+		sharedhttp.ErrorResponse(w, http.StatusNotFound, "Enlace no encontrado")
+		return nil, "", false
+	}
+
+	return shortLink, "", true
+}
+
+// setRedirectCacheHeaders permite cachear las redirecciones permanentes con
+// destino fijo; el resto usa no-store para que cada click llegue al servidor
+// y se cuente. Un enlace que expira no se cachea más allá de su vencimiento
+func (h *ShortLinkHandler) setRedirectCacheHeaders(w http.ResponseWriter, shortLink *model.ShortLink) {
+	maxAge := h.config.PermanentRedirectMaxAge
+	now := time.Now()
+	if shortLink.ExpiresAt != nil {
+		maxAge = min(maxAge, shortLink.ExpiresAt.Sub(now))
+	}
+	if shortLink.EndsAt != nil {
+		maxAge = min(maxAge, shortLink.EndsAt.Sub(now))
+	}
+
+	cacheable := shortLink.IsPermanentRedirect() &&
+		!shortLink.Quarantined &&
+		!shortLink.VariesByVisitor() &&
+		!shortLink.HasPassword() &&
+		shortLink.MaxClicks == nil &&
+		maxAge >= time.Second
+
+	if !cacheable {
+		w.Header().Set("Cache-Control", "no-store")
+		return
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int64(maxAge/time.Second)))
+}
+
+// setClickUTM registra en el click los UTM con los que sale la visita, ya
+// combinados los del enlace y los reenviados desde la query string
+func setClickUTM(click *analyticsModel.Click, target string) {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return
+	}
+
+	query := parsed.Query()
+	click.UTMSource = query.Get("utm_source")
+	click.UTMMedium = query.Get("utm_medium")
+	click.UTMCampaign = query.Get("utm_campaign")
+	click.UTMTerm = query.Get("utm_term")
+	click.UTMContent = query.Get("utm_content")
+}
+
+func renderClickLimitPage(w http.ResponseWriter) {
+	sharedhttp.RenderMessagePage(w, http.StatusGone, "Enlace agotado",
+		"Este enlace ya alcanzó el número máximo de visitas permitidas.")
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+	sharedhttp "short-go/internal/shared/http"
+	"short-go/internal/short-links/domain/model"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RevisionSettingsResponse es la configuración guardada en una revisión;
+// de la contraseña solo se indica si existía
+type RevisionSettingsResponse struct {
+	OriginalUrl       string             `json:"originalUrl"`
+	ExpiresAt         string             `json:"expiresAt,omitempty"`
+	PasswordProtected bool               `json:"passwordProtected"`
+	MaxClicks         *int64             `json:"maxClicks,omitempty"`
+	RedirectType      int                `json:"redirectType"`
+	ForwardQuery      bool               `json:"forwardQuery"`
+	QueryPriority     string             `json:"queryPriority"`
+	ForwardPath       bool               `json:"forwardPath"`
+	UTM               model.UTM          `json:"utm"`
+	StartsAt          string             `json:"startsAt,omitempty"`
+	EndsAt            string             `json:"endsAt,omitempty"`
+	InactiveURL       string             `json:"inactiveUrl,omitempty"`
+	GeoRules          []model.GeoRule    `json:"geoRules,omitempty"`
+	DeviceRules       []model.DeviceRule `json:"deviceRules,omitempty"`
+	Variants          []model.Variant    `json:"variants,omitempty"`
+	Title             string             `json:"title,omitempty"`
+	Tags              []string           `json:"tags,omitempty"`
+	Folder            string             `json:"folder,omitempty"`
+	Domain            string             `json:"domain,omitempty"`
+}
+
+type RevisionResponse struct {
+	ID             uint                     `json:"id"`
+	Action         string                   `json:"action"`
+	OldUrl         string                   `json:"oldUrl,omitempty"`
+	NewUrl         string                   `json:"newUrl"`
+	ActorType      string                   `json:"actorType,omitempty"`
+	ActorUserID    *string                  `json:"actorUserId,omitempty"`
+	RestoredFromID *uint                    `json:"restoredFromId,omitempty"`
+	CreatedAt      string                   `json:"createdAt"`
+	Settings       RevisionSettingsResponse `json:"settings"`
+}
+
+type RevisionListResponse struct {
+	Items    []RevisionResponse `json:"items"`
+	Total    int64              `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"pageSize"`
+}
+
+// ListRevisions - GET /api/short-links/{code}/revisions
+func (h *ShortLinkHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	page, pageSize, err := sharedhttp.ParsePagination(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.shortLinkService.ListRevisions(code, h.linkAccess(r), page, pageSize)
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	items := make([]RevisionResponse, len(result.Items))
+	for i, revision := range result.Items {
+		items[i] = toRevisionResponse(revision)
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, RevisionListResponse{
+		Items:    items,
+		Total:    result.Total,
+		Page:     result.Page,
+		PageSize: result.PageSize,
+	})
+}
+
+// RestoreRevision - POST /api/short-links/{code}/revisions/{revisionId}/restore
+func (h *ShortLinkHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	revisionID, err := strconv.ParseUint(chi.URLParam(r, "revisionId"), 10, 64)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "Identificador de revisión inválido")
+		return
+	}
+
+	shortLink, err := h.shortLinkService.RestoreRevision(code, h.linkAccess(r), uint(revisionID))
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, h.toResponse(shortLink))
+}
+
+// toRevisionResponse construye la respuesta pública de una revisión
+func toRevisionResponse(revision *model.Revision) RevisionResponse {
+	settings := revision.Settings
+
+	return RevisionResponse{
+		ID:             revision.ID,
+		Action:         revision.Action,
+		OldUrl:         revision.OldURL,
+		NewUrl:         revision.NewURL,
+		ActorType:      revision.ActorType,
+		ActorUserID:    revision.ActorUserID,
+		RestoredFromID: revision.RestoredFromID,
+		CreatedAt:      revision.CreatedAt.Format(time.RFC3339),
+		Settings: RevisionSettingsResponse{
+			OriginalUrl:       settings.OriginalURL,
+			ExpiresAt:         formatOptionalTime(settings.ExpiresAt),
+			PasswordProtected: settings.PasswordHash != "",
+			MaxClicks:         settings.MaxClicks,
+			RedirectType:      settings.RedirectType,
+			ForwardQuery:      settings.ForwardQuery,
+			QueryPriority:     settings.QueryPriority,
+			ForwardPath:       settings.ForwardPath,
+			UTM:               settings.UTM,
+			StartsAt:          formatOptionalTime(settings.StartsAt),
+			EndsAt:            formatOptionalTime(settings.EndsAt),
+			InactiveURL:       settings.InactiveURL,
+			GeoRules:          settings.GeoRules,
+			DeviceRules:       settings.DeviceRules,
+			Variants:          settings.Variants,
+			Title:             settings.Title,
+			Tags:              settings.Tags,
+			Folder:            settings.Folder,
+			Domain:            settings.Domain,
+		},
+	}
+}
@@ -2,15 +2,22 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"short-go/config"
 	analyticsService "short-go/internal/analytics/application/service"
 	sharedContext "short-go/internal/shared/context"
+	"short-go/internal/shared/geoip"
 	sharedhttp "short-go/internal/shared/http"
 	format "short-go/internal/shared/http/utils"
+	"short-go/internal/shared/ratelimit"
+	"short-go/internal/shared/timeutil"
+	"short-go/internal/shared/urlutil"
 	sharedValidation "short-go/internal/shared/validation"
 	"short-go/internal/short-links/application/service"
+	"short-go/internal/short-links/domain/model"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -18,36 +25,216 @@ import (
 )
 
 type ShortLinkHandler struct {
-	shortLinkService *service.ShortLinkService
-	analyticsService *analyticsService.AnalyticsService
-	validator        *validator.Validate
-	config           *config.Config
+	shortLinkService  *service.ShortLinkService
+	moderationService *service.ModerationService
+	analyticsService  *analyticsService.AnalyticsService
+	geoResolver       geoip.Resolver
+	validator         *validator.Validate
+	config            *config.Config
+	unlockSigner      *unlockCookieSigner
+	unlockLimiter     *ratelimit.Limiter
+	quarantineSigner  *quarantineConfirmSigner
+	reportLimiter     *ratelimit.Limiter
+	// Host del dominio compartido (config.Domain) normalizado
+	sharedHost string
 }
 
 func NewShortLinkHandler(
-	shortLinkService *service.ShortLinkService, 
-	analyticsService *analyticsService.AnalyticsService, 
+	shortLinkService *service.ShortLinkService,
+	moderationService *service.ModerationService,
+	analyticsService *analyticsService.AnalyticsService,
+	geoResolver geoip.Resolver,
 	cfg *config.Config,
 ) *ShortLinkHandler {
+	sharedHost, _ := urlutil.NormalizeHost(cfg.Domain)
+
 	return &ShortLinkHandler{
-		shortLinkService: shortLinkService,
-		analyticsService: analyticsService,
-		validator:        sharedValidation.NewValidator(),
-		config:           cfg,
+		shortLinkService:  shortLinkService,
+		moderationService: moderationService,
+		analyticsService:  analyticsService,
+		geoResolver:       geoResolver,
+		validator:         sharedValidation.NewValidator(),
+		config:            cfg,
+		unlockSigner:      newUnlockCookieSigner(cfg.LinkUnlockSecret, cfg.LinkUnlockTTL, strings.HasPrefix(cfg.Domain, "https://")),
+		unlockLimiter:     ratelimit.NewLimiter(cfg.LinkUnlockMaxAttempts, cfg.LinkUnlockAttemptWindow),
+		quarantineSigner:  newQuarantineConfirmSigner(cfg.LinkUnlockSecret),
+		reportLimiter:     ratelimit.NewLimiter(cfg.ReportMaxPerWindow, cfg.ReportWindow),
+		sharedHost:        sharedHost,
+	}
+}
+
+// ExpirationRequest permite indicar una fecha absoluta (RFC3339), una
+// duración relativa ("72h", "30d") o que el enlace nunca expire
+type ExpirationRequest struct {
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+	ExpiresIn    string     `json:"expiresIn,omitempty"`
+	NeverExpires bool       `json:"neverExpires,omitempty"`
+}
+
+// ScheduleRequest define la ventana de activación (RFC3339) y el destino
+// para visitas fuera de ella
+type ScheduleRequest struct {
+	StartsAt    *time.Time `json:"startsAt,omitempty"`
+	EndsAt      *time.Time `json:"endsAt,omitempty"`
+	InactiveURL *string    `json:"inactiveUrl,omitempty"`
+}
+
+func (req ScheduleRequest) toInput() service.ScheduleInput {
+	return service.ScheduleInput{
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		InactiveURL: req.InactiveURL,
+	}
+}
+
+// PassthroughRequest configura el reenvío de la query string y de la ruta
+// adicional (/{code}/ruta) al destino
+type PassthroughRequest struct {
+	ForwardQuery *bool `json:"forwardQuery,omitempty"`
+	// Qué lado gana si un parámetro ya existe en el destino: destination (por defecto) o incoming
+	QueryPriority *string `json:"queryPriority,omitempty"`
+	ForwardPath   *bool   `json:"forwardPath,omitempty"`
+}
+
+func (req PassthroughRequest) toInput() service.PassthroughInput {
+	return service.PassthroughInput{
+		ForwardQuery:  req.ForwardQuery,
+		QueryPriority: req.QueryPriority,
+		ForwardPath:   req.ForwardPath,
+	}
+}
+
+// UTMRequest son los parámetros de campaña del enlace; en una edición los
+// campos omitidos no cambian y "" elimina el parámetro
+type UTMRequest struct {
+	Source   *string `json:"source,omitempty"`
+	Medium   *string `json:"medium,omitempty"`
+	Campaign *string `json:"campaign,omitempty"`
+	Term     *string `json:"term,omitempty"`
+	Content  *string `json:"content,omitempty"`
+}
+
+func (req *UTMRequest) toInput() service.UTMInput {
+	if req == nil {
+		return service.UTMInput{}
+	}
+	return service.UTMInput{
+		Source:   req.Source,
+		Medium:   req.Medium,
+		Campaign: req.Campaign,
+		Term:     req.Term,
+		Content:  req.Content,
 	}
 }
 
 type ShortLinkRequest struct {
 	OriginalURL string `json:"originalUrl" validate:"required"`
+	Alias       string `json:"alias,omitempty"`
+	Password    string `json:"password,omitempty"`
+	MaxClicks   *int64 `json:"maxClicks,omitempty"`
+	// 301/308 para SEO, 307 para conservar método y cuerpo; por defecto 302
+	RedirectType int `json:"redirectType,omitempty" validate:"omitempty,oneof=301 302 307 308"`
+	// Reglas por país evaluadas en orden; originalUrl es el destino por defecto
+	GeoRules []model.GeoRule `json:"geoRules,omitempty"`
+	// Reglas por plataforma (ios, android, mobile, desktop...); tienen prioridad sobre las de país
+	DeviceRules []model.DeviceRule `json:"deviceRules,omitempty"`
+	// Rotación A/B: destinos con pesos en porcentaje que suman 100
+	Variants []model.Variant `json:"variants,omitempty"`
+	// utm_source, utm_medium... que se agregan al destino al redirigir
+	UTM    *UTMRequest `json:"utm,omitempty"`
+	Title  string      `json:"title,omitempty"`
+	Tags   []string    `json:"tags,omitempty"`
+	Folder string      `json:"folder,omitempty"`
+	// Dominio personalizado verificado de la cuenta
+	Domain string `json:"domain,omitempty"`
+	ExpirationRequest
+	ScheduleRequest
+	PassthroughRequest
+}
+
+type UpdateShortLinkRequest struct {
+	OriginalURL *string `json:"originalUrl,omitempty"`
+	// "" elimina la contraseña; omitido la deja sin cambios
+	Password *string `json:"password,omitempty"`
+	// 0 elimina el límite de clicks
+	MaxClicks    *int64 `json:"maxClicks,omitempty"`
+	RedirectType *int   `json:"redirectType,omitempty" validate:"omitempty,oneof=301 302 307 308"`
+	// [] elimina las reglas por país; omitido las deja sin cambios
+	GeoRules *[]model.GeoRule `json:"geoRules,omitempty"`
+	// [] elimina las reglas por plataforma; omitido las deja sin cambios
+	DeviceRules *[]model.DeviceRule `json:"deviceRules,omitempty"`
+	// [] detiene la rotación A/B; omitido la deja sin cambios
+	Variants *[]model.Variant `json:"variants,omitempty"`
+	UTM      *UTMRequest      `json:"utm,omitempty"`
+	// "" elimina el título o saca el enlace de su carpeta; [] quita las etiquetas
+	Title  *string   `json:"title,omitempty"`
+	Tags   *[]string `json:"tags,omitempty"`
+	Folder *string   `json:"folder,omitempty"`
+	// "" devuelve el enlace al dominio compartido
+	Domain *string `json:"domain,omitempty"`
+	ExpirationRequest
+	ScheduleRequest
+	PassthroughRequest
+	// Elimina la ventana de activación y el destino alternativo
+	ClearSchedule bool `json:"clearSchedule,omitempty"`
+}
+
+type ClaimShortLinksRequest struct {
+	ManagementTokens []string `json:"managementTokens" validate:"required,min=1,max=50,dive,required"`
+}
+
+type ClaimShortLinksResponse struct {
+	Claimed  []ShortLinkResponse `json:"claimed"`
+	Rejected int                 `json:"rejected"`
 }
 
 type ShortLinkResponse struct {
+	Code        string  `json:"code"`
 	ShortUrl    string  `json:"shortUrl"`
 	OriginalUrl string  `json:"originalUrl"`
 	StatsUrl    string  `json:"statsUrl"`
 	QrUrl       string  `json:"qrUrl,omitempty"`
 	ExpiresAt   string  `json:"expiresAt,omitempty"`
 	UserID      *string `json:"userId,omitempty"`
+	TotalClicks int64   `json:"totalClicks"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+
+	PasswordProtected bool      `json:"passwordProtected"`
+	MaxClicks         *int64    `json:"maxClicks,omitempty"`
+	RedirectType      int       `json:"redirectType"`
+	ForwardQuery      bool      `json:"forwardQuery"`
+	QueryPriority     string    `json:"queryPriority"`
+	ForwardPath       bool      `json:"forwardPath"`
+	UTM               model.UTM `json:"utm"`
+	Quarantined       bool      `json:"quarantined"`
+	QuarantineReason  string    `json:"quarantineReason,omitempty"`
+	ModerationStatus  string    `json:"moderationStatus"`
+	ModerationReason  string    `json:"moderationReason,omitempty"`
+	RemainingClicks   *int64    `json:"remainingClicks,omitempty"`
+	StartsAt          string    `json:"startsAt,omitempty"`
+	EndsAt            string    `json:"endsAt,omitempty"`
+	InactiveURL       string    `json:"inactiveUrl,omitempty"`
+
+	GeoRules    []model.GeoRule    `json:"geoRules,omitempty"`
+	DeviceRules []model.DeviceRule `json:"deviceRules,omitempty"`
+	Variants    []model.Variant    `json:"variants,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+	Folder      string             `json:"folder,omitempty"`
+	Domain      string             `json:"domain,omitempty"`
+	ArchivedAt  string             `json:"archivedAt,omitempty"`
+	DeletedAt   string             `json:"deletedAt,omitempty"`
+
+	// Solo se entrega al crear: permite gestionar el enlace sin cuenta
+	ManagementToken string `json:"managementToken,omitempty"`
+}
+
+type ShortLinkListResponse struct {
+	Items    []ShortLinkResponse `json:"items"`
+	Total    int64               `json:"total"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"pageSize"`
 }
 
 // CreateShortLink - POST /api/short-links
@@ -70,52 +257,361 @@ func (h *ShortLinkHandler) CreateShortLink(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	shortLink, err := h.shortLinkService.CreateShortLink(req.OriginalURL, userID)
+	expiration, err := req.ExpirationRequest.toInput()
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shortLink, err := h.shortLinkService.CreateShortLink(service.CreateShortLinkInput{
+		OriginalURL:  req.OriginalURL,
+		Alias:        req.Alias,
+		UserID:       userID,
+		Expiration:   expiration,
+		Password:     req.Password,
+		MaxClicks:    req.MaxClicks,
+		RedirectType: req.RedirectType,
+		Passthrough:  req.PassthroughRequest.toInput(),
+		UTM:          req.UTM.toInput(),
+		Schedule:     req.ScheduleRequest.toInput(),
+		GeoRules:     req.GeoRules,
+		DeviceRules:  req.DeviceRules,
+		Variants:     req.Variants,
+		Title:        req.Title,
+		Tags:         req.Tags,
+		Folder:       req.Folder,
+		Domain:       req.Domain,
+	})
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	resp := h.toResponse(shortLink)
+	resp.ManagementToken = shortLink.ManagementToken
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, resp)
+}
+
+// ListShortLinks - GET /api/short-links
+func (h *ShortLinkHandler) ListShortLinks(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+	query := r.URL.Query()
+
+	opts := model.ListOptions{SortBy: model.SortByCreatedAt, SortDesc: true}
+
+	var err error
+	if opts.Page, opts.PageSize, err = sharedhttp.ParsePagination(r); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch sort := query.Get("sort"); sort {
+	case "", model.SortByCreatedAt, model.SortByClicks:
+		if sort != "" {
+			opts.SortBy = sort
+		}
+	default:
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "Parámetro 'sort' inválido: usa 'createdAt' o 'clicks'")
+		return
+	}
+
+	switch order := query.Get("order"); order {
+	case "", "desc":
+	case "asc":
+		opts.SortDesc = false
+	default:
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "Parámetro 'order' inválido: usa 'asc' o 'desc'")
+		return
+	}
+
+	filter, err := parseListFilter(query)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	opts.Filter = filter
+
+	page, err := h.shortLinkService.ListUserShortLinks(userID, opts)
 	if err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		switch err {
+		case service.ErrInvalidTags, service.ErrInvalidFolder, service.ErrInvalidDestinationHost, service.ErrInvalidDomainFilter:
+			h.manageErrorResponse(w, err)
+		default:
+			sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener los enlaces")
+		}
 		return
 	}
 
-	// Construcción de Enlaces
-	baseUrl := h.config.Domain
-	if h.config.Port != "" && h.config.Domain == "http://localhost" {
-		baseUrl = fmt.Sprintf("%s:%s", h.config.Domain, h.config.Port)
+	items := make([]ShortLinkResponse, len(page.Items))
+	for i, shortLink := range page.Items {
+		items[i] = h.toResponse(shortLink)
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, ShortLinkListResponse{
+		Items:    items,
+		Total:    page.Total,
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	})
+}
+
+// GetShortLink - GET /api/short-links/{code}
+func (h *ShortLinkHandler) GetShortLink(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	shortLink, err := h.shortLinkService.GetManagedShortLink(code, h.linkAccess(r))
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
 	}
 
-	fullShortUrl := fmt.Sprintf("%s/%s", baseUrl, shortLink.Code)
+	sharedhttp.SuccessResponse(w, http.StatusOK, h.toResponse(shortLink))
+}
+
+// UpdateShortLink - PATCH /api/short-links/{code}
+func (h *ShortLinkHandler) UpdateShortLink(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	var req UpdateShortLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	expiration, err := req.ExpirationRequest.toInput()
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	schedule := req.ScheduleRequest.toInput()
+	schedule.Clear = req.ClearSchedule
+	passthrough := req.PassthroughRequest.toInput()
+
+	var utm *service.UTMInput
+	if req.UTM != nil {
+		input := req.UTM.toInput()
+		utm = &input
+	}
+
+	shortLink, err := h.shortLinkService.UpdateShortLink(code, h.linkAccess(r), service.UpdateShortLinkInput{
+		OriginalURL:  req.OriginalURL,
+		Expiration:   &expiration,
+		Password:     req.Password,
+		MaxClicks:    req.MaxClicks,
+		RedirectType: req.RedirectType,
+		Passthrough:  &passthrough,
+		UTM:          utm,
+		Schedule:     &schedule,
+		GeoRules:     req.GeoRules,
+		DeviceRules:  req.DeviceRules,
+		Variants:     req.Variants,
+		Title:        req.Title,
+		Tags:         req.Tags,
+		Folder:       req.Folder,
+		Domain:       req.Domain,
+	})
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, h.toResponse(shortLink))
+}
+
+// DeleteShortLink - DELETE /api/short-links/{code}
+func (h *ShortLinkHandler) DeleteShortLink(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	if err := h.shortLinkService.DeleteShortLink(code, h.linkAccess(r)); err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, map[string]string{"message": "Enlace movido a la papelera"})
+}
+
+// ClaimShortLinks - POST /api/short-links/claim
+func (h *ShortLinkHandler) ClaimShortLinks(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req ClaimShortLinksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.shortLinkService.ClaimShortLinks(userID, req.ManagementTokens)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al reclamar los enlaces")
+		return
+	}
+
+	claimed := make([]ShortLinkResponse, len(result.Claimed))
+	for i, shortLink := range result.Claimed {
+		claimed[i] = h.toResponse(shortLink)
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, ClaimShortLinksResponse{
+		Claimed:  claimed,
+		Rejected: result.Rejected,
+	})
+}
+
+// ------------------------------ HELPERS -----------------------------------
+// requestHost devuelve el dominio personalizado por el que llegó la
+// petición, o "" si llegó por el dominio compartido
+func (h *ShortLinkHandler) requestHost(r *http.Request) string {
+	host, err := urlutil.NormalizeHost(r.Host)
+	if err != nil || host == h.sharedHost {
+		return ""
+	}
+	return host
+}
+
+// toInput convierte la expiración solicitada al formato del servicio
+func (req ExpirationRequest) toInput() (service.ExpirationInput, error) {
+	input := service.ExpirationInput{
+		ExpiresAt:    req.ExpiresAt,
+		NeverExpires: req.NeverExpires,
+	}
+
+	if req.ExpiresIn != "" {
+		expiresIn, err := timeutil.ParseDuration(req.ExpiresIn)
+		if err != nil || expiresIn <= 0 {
+			return input, errors.New("El campo 'expiresIn' debe ser una duración positiva, por ejemplo '72h' o '30d'")
+		}
+		input.ExpiresIn = expiresIn
+	}
+
+	return input, nil
+}
+
+// linkAccess reúne las credenciales de gestión de la petición: el usuario
+// autenticado (si existe) y el token enviado en el header
+func (h *ShortLinkHandler) linkAccess(r *http.Request) service.LinkAccess {
+	return service.LinkAccess{
+		UserID:          sharedContext.GetUserID(r.Context()),
+		ManagementToken: r.Header.Get(sharedhttp.ManagementTokenHeader),
+	}
+}
+
+// toResponse construye la respuesta pública de un enlace
+func (h *ShortLinkHandler) toResponse(shortLink *model.ShortLink) ShortLinkResponse {
+	baseUrl := sharedhttp.BaseURL(h.config)
+
+	fullShortUrl := fmt.Sprintf("%s/%s", sharedhttp.ShortURLBase(h.config, shortLink.Domain), shortLink.Code)
 	fullQrUrl := fmt.Sprintf("%s/api/qr/%s", baseUrl, shortLink.Code)
 
-	// Estructura: <Base>/api/stats/<Code>?token=<Token>
-	fullStatsUrl := fmt.Sprintf("%s/api/stats/%s?token=%s", baseUrl, shortLink.Code, shortLink.ManagementToken)
+	// Estructura: <Base>/api/stats/<Code>; el token viaja en X-Management-Token
+	fullStatsUrl := fmt.Sprintf("%s/api/stats/%s", baseUrl, shortLink.Code)
 
-	resp := ShortLinkResponse{
+	var remainingClicks *int64
+	if shortLink.MaxClicks != nil {
+		remaining := max(*shortLink.MaxClicks-shortLink.ConsumedClicks, 0)
+		remainingClicks = &remaining
+	}
+
+	return ShortLinkResponse{
+		Code:        shortLink.Code,
 		ShortUrl:    fullShortUrl,
 		OriginalUrl: shortLink.OriginalURL,
 		StatsUrl:    fullStatsUrl,
 		QrUrl:       fullQrUrl,
-		ExpiresAt:   shortLink.ExpiresAt.Format(time.RFC3339),
+		ExpiresAt:   formatOptionalTime(shortLink.ExpiresAt),
 		UserID:      shortLink.UserID,
+		TotalClicks: shortLink.TotalClicks,
+		CreatedAt:   shortLink.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   shortLink.UpdatedAt.Format(time.RFC3339),
+
+		PasswordProtected: shortLink.HasPassword(),
+		MaxClicks:         shortLink.MaxClicks,
+		RedirectType:      shortLink.RedirectType,
+		ForwardQuery:      shortLink.ForwardQuery,
+		QueryPriority:     shortLink.QueryPriority,
+		ForwardPath:       shortLink.ForwardPath,
+		UTM:               shortLink.UTM,
+		Quarantined:       shortLink.Quarantined,
+		QuarantineReason:  shortLink.QuarantineReason,
+		ModerationStatus:  shortLink.ModerationStatus,
+		ModerationReason:  shortLink.ModerationReason,
+		RemainingClicks:   remainingClicks,
+		StartsAt:          formatOptionalTime(shortLink.StartsAt),
+		EndsAt:            formatOptionalTime(shortLink.EndsAt),
+		InactiveURL:       shortLink.InactiveURL,
+
+		GeoRules:    shortLink.GeoRules,
+		DeviceRules: shortLink.DeviceRules,
+		Variants:    shortLink.Variants,
+		Title:       shortLink.Title,
+		Tags:        shortLink.Tags,
+		Folder:      shortLink.Folder,
+		Domain:      shortLink.Domain,
+		ArchivedAt:  formatOptionalTime(shortLink.ArchivedAt),
+		DeletedAt:   formatOptionalTime(shortLink.DeletedAt),
 	}
+}
 
-	sharedhttp.SuccessResponse(w, http.StatusCreated, resp)
+// formatOptionalTime formatea en RFC3339 o retorna "" si no hay fecha
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
 }
 
-// Redirect - GET /{code}
-func (h *ShortLinkHandler) Redirect(w http.ResponseWriter, r *http.Request) {
-	code := chi.URLParam(r, "code")
+// manageErrorResponse traduce errores del servicio a códigos HTTP
+func (h *ShortLinkHandler) manageErrorResponse(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
 
-	shortLink, err := h.shortLinkService.GetShortLinkByCode(code)
-	if err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusNotFound, "Enlace no encontrado")
+	// Los errores de generación envuelven la causa interna, que no se expone
+	if errors.Is(err, service.ErrCodeGeneration) {
+		sharedhttp.ErrorResponse(w, status, service.ErrCodeGeneration.Error())
 		return
 	}
 
-	// Extrae los metadatos básisocs
-	ip := r.RemoteAddr  // Nota: En producción, usa r.Header.Get("X-Forwarded-For")
-	userAgent := r.UserAgent()
-	referrer := r.Referer()
-
-	h.analyticsService.TrackClick(code, ip, userAgent, referrer)
+	switch err {
+	case service.ErrShortLinkNotFound, service.ErrRevisionNotFound:
+		status = http.StatusNotFound
+	case service.ErrUnauthorizedAccess, service.ErrShortLinkBanned:
+		status = http.StatusForbidden
+	case service.ErrManagementTokenInvalid:
+		status = http.StatusUnauthorized
+	case service.ErrInvalidOriginalURL, service.ErrURLSchemeNotAllowed, service.ErrURLTooLong,
+		service.ErrRedirectLoop, service.ErrInvalidExpiration,
+		service.ErrExpirationTooFar, service.ErrExpirationConflict, service.ErrNeverExpiresNotAllowed,
+		service.ErrLinkPasswordShort, service.ErrLinkPasswordLong, service.ErrInvalidMaxClicks, service.ErrInvalidSchedule,
+		service.ErrInvalidRedirect, service.ErrInvalidQueryPriority,
+		service.ErrInvalidUTM,
+		service.ErrInvalidGeoRules, service.ErrInvalidDeviceRules,
+		service.ErrInvalidVariants, service.ErrInvalidTags,
+		service.ErrInvalidTitle, service.ErrInvalidFolder, service.ErrInvalidDestinationHost, service.ErrInvalidDomainFilter,
+		service.ErrBulkEmpty, service.ErrBulkTooManyRows,
+		service.ErrAliasInvalid, service.ErrAliasReserved,
+		service.ErrDomainRequiresAuth, service.ErrDomainNotVerified,
+		service.ErrInvalidReportReason, service.ErrReportDetailsTooLong,
+		service.ErrInvalidReportStatus, service.ErrModerationReasonRequired:
+		status = http.StatusBadRequest
+	case service.ErrUnsafeURL:
+		status = http.StatusUnprocessableEntity
+	case service.ErrAliasRequiresAuth:
+		status = http.StatusUnauthorized
+	case service.ErrAliasTaken, service.ErrRevisionExpired:
+		status = http.StatusConflict
+	case service.ErrCodeSpaceExhausted:
+		status = http.StatusServiceUnavailable
+	}
 
-	http.Redirect(w, r, shortLink.OriginalURL, http.StatusFound)
-}
\ No newline at end of file
+	sharedhttp.ErrorResponse(w, status, err.Error())
+}
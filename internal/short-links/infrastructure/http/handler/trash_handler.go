@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	sharedContext "short-go/internal/shared/context"
+	sharedhttp "short-go/internal/shared/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ListTrash - GET /api/short-links/trash
+func (h *ShortLinkHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	page, pageSize, err := sharedhttp.ParsePagination(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.shortLinkService.ListTrash(userID, page, pageSize)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener la papelera")
+		return
+	}
+
+	items := make([]ShortLinkResponse, len(result.Items))
+	for i, shortLink := range result.Items {
+		items[i] = h.toResponse(shortLink)
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, ShortLinkListResponse{
+		Items:    items,
+		Total:    result.Total,
+		Page:     result.Page,
+		PageSize: result.PageSize,
+	})
+}
+
+// RestoreDeletedShortLink - POST /api/short-links/trash/{code}/restore
+func (h *ShortLinkHandler) RestoreDeletedShortLink(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	shortLink, err := h.shortLinkService.RestoreDeletedShortLink(code, h.linkAccess(r))
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, h.toResponse(shortLink))
+}
+
+// ArchiveShortLink - POST /api/short-links/{code}/archive
+func (h *ShortLinkHandler) ArchiveShortLink(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, true)
+}
+
+// UnarchiveShortLink - POST /api/short-links/{code}/unarchive
+func (h *ShortLinkHandler) UnarchiveShortLink(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, false)
+}
+
+func (h *ShortLinkHandler) setArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	code := chi.URLParam(r, "code")
+
+	shortLink, err := h.shortLinkService.ArchiveShortLink(code, h.linkAccess(r), archived)
+	if err != nil {
+		h.manageErrorResponse(w, err)
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, h.toResponse(shortLink))
+}
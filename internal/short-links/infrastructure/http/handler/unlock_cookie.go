@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"short-go/internal/short-links/domain/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const unlockCookiePrefix = "sg_unlock_"
+
+// unlockCookieSigner emite y valida la cookie que recuerda el desbloqueo
+// de un enlace con contraseña. La firma incluye el hash de la contraseña,
+// así que cambiarla invalida los desbloqueos anteriores
+type unlockCookieSigner struct {
+	secret []byte
+	ttl    time.Duration
+	// Solo HTTPS cuando el dominio público es https
+	secure bool
+}
+
+func newUnlockCookieSigner(secret string, ttl time.Duration, secure bool) *unlockCookieSigner {
+	return &unlockCookieSigner{secret: []byte(secret), ttl: ttl, secure: secure}
+}
+
+// set guarda la cookie de desbloqueo con formato <expiraUnix>.<firma>
+func (s *unlockCookieSigner) set(w http.ResponseWriter, shortLink *model.ShortLink) {
+	expiresAt := time.Now().Add(s.ttl)
+	expiresUnix := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     unlockCookiePrefix + shortLink.Code,
+		Value:    expiresUnix + "." + s.sign(shortLink, expiresUnix),
+		Path:     "/" + shortLink.Code,
+		Expires:  expiresAt,
+		MaxAge:   int(s.ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   s.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// verify indica si la petición trae una cookie de desbloqueo válida y vigente
+func (s *unlockCookieSigner) verify(r *http.Request, shortLink *model.ShortLink) bool {
+	cookie, err := r.Cookie(unlockCookiePrefix + shortLink.Code)
+	if err != nil {
+		return false
+	}
+
+	expiresUnix, signature, found := strings.Cut(cookie.Value, ".")
+	if !found {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(expiresUnix, 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(s.sign(shortLink, expiresUnix)))
+}
+
+func (s *unlockCookieSigner) sign(shortLink *model.ShortLink, expiresUnix string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(shortLink.Code + "|" + expiresUnix + "|" + shortLink.PasswordHash))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"short-go/internal/short-links/domain/model"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestUnlockCookieSigner(t *testing.T) {
+	signer := newUnlockCookieSigner("secreto", time.Hour, true)
+	link := &model.ShortLink{Code: "abc123", PasswordHash: "hash-1"}
+
+	recorder := httptest.NewRecorder()
+	signer.set(recorder, link)
+	cookies := recorder.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("cookies emitidas = %d, se esperaba 1", len(cookies))
+	}
+	issued := cookies[0]
+	if issued.Name != unlockCookiePrefix+link.Code || issued.Path != "/"+link.Code || !issued.HttpOnly || !issued.Secure {
+		t.Fatalf("atributos de la cookie inesperados: %+v", issued)
+	}
+
+	expiredUnix := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	expired := expiredUnix + "." + signer.sign(link, expiredUnix)
+
+	tests := []struct {
+		name   string
+		signer *unlockCookieSigner
+		cookie *http.Cookie
+		link   *model.ShortLink
+		want   bool
+	}{
+		{name: "cookie emitida", signer: signer, cookie: issued, link: link, want: true},
+		{name: "sin cookie", signer: signer, link: link},
+		{name: "contraseña cambiada", signer: signer, cookie: issued, link: &model.ShortLink{Code: "abc123", PasswordHash: "hash-2"}},
+		{name: "cookie de otro enlace", signer: signer, cookie: &http.Cookie{Name: unlockCookiePrefix + "xyz789", Value: issued.Value}, link: &model.ShortLink{Code: "xyz789", PasswordHash: "hash-1"}},
+		{name: "otro secreto", signer: newUnlockCookieSigner("otro", time.Hour, true), cookie: issued, link: link},
+		{name: "vencida", signer: signer, cookie: &http.Cookie{Name: issued.Name, Value: expired}, link: link},
+		{name: "firma alterada", signer: signer, cookie: &http.Cookie{Name: issued.Name, Value: issued.Value + "x"}, link: link},
+		{name: "sin separador", signer: signer, cookie: &http.Cookie{Name: issued.Name, Value: "1"}, link: link},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.link.Code, nil)
+			if tt.cookie != nil {
+				r.AddCookie(&http.Cookie{Name: tt.cookie.Name, Value: tt.cookie.Value})
+			}
+			if got := tt.signer.verify(r, tt.link); got != tt.want {
+				t.Errorf("verify = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"short-go/internal/short-links/domain/model"
+	"strings"
+)
+
+// platformFromUserAgent obtiene la plataforma del visitante a partir del
+// User-Agent. El orden importa: los UA de Android incluyen "Linux" y los de
+// iOS incluyen "like Mac OS X". iPadOS 13+ se presenta como macOS de escritorio
+func platformFromUserAgent(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	switch {
+	case ua == "":
+		return model.PlatformOther
+	case strings.Contains(ua, "windows phone"):
+		return model.PlatformOther
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return model.PlatformIOS
+	case strings.Contains(ua, "android"):
+		return model.PlatformAndroid
+	case strings.Contains(ua, "windows"):
+		return model.PlatformWindows
+	case strings.Contains(ua, "macintosh"), strings.Contains(ua, "mac os x"):
+		return model.PlatformMacOS
+	case strings.Contains(ua, "cros"):
+		return model.PlatformOther
+	case strings.Contains(ua, "linux"), strings.Contains(ua, "x11"):
+		return model.PlatformLinux
+	}
+
+	return model.PlatformOther
+}
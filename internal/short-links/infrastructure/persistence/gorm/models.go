@@ -3,11 +3,13 @@ package gorm
 import (
 	"time"
 
+	"gorm.io/gorm"
+
 	authGormModels "short-go/internal/auth/infrastructure/persistence/gorm"
 )
 
 type ShortLinkModel struct {
-	Code string `gorm:"primaryKey;size:32"`
+	Code        string `gorm:"primaryKey;size:32"`
 	OriginalURL string `gorm:"not null"`
 
 	// Clave de la lógica anónima/autenticada
@@ -17,8 +19,53 @@ type ShortLinkModel struct {
 
 	// Fin de la lógica Clave
 	ExpiresAt *time.Time
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	// Hash bcrypt de la contraseña de acceso (nil = enlace público)
+	PasswordHash *string `gorm:"type:text"`
+
+	// Límite de clicks (nil = ilimitado). consumed_clicks se incrementa
+	// atómicamente en cada redirección de un enlace limitado
+	MaxClicks        *int64
+	RedirectType     int      `gorm:"not null;default:302"`
+	ForwardQuery     bool     `gorm:"not null;default:false"`
+	QueryPriority    string   `gorm:"size:16;not null;default:'destination'"`
+	ForwardPath      bool     `gorm:"not null;default:false"`
+	UTM              UTMModel `gorm:"embedded;embeddedPrefix:utm_"`
+	Quarantined      bool     `gorm:"not null;default:false"`
+	QuarantineReason *string  `gorm:"type:text"`
+	ConsumedClicks   int64    `gorm:"not null;default:0"`
+
+	// Moderación: solo la modifica SetModeration
+	ModerationStatus string  `gorm:"size:16;not null;default:'active';index"`
+	ModerationReason *string `gorm:"type:text"`
+	ModeratedBy      *string `gorm:"type:text"`
+	ModeratedAt      *time.Time
+
+	// Ventana de activación y destino alternativo fuera de ella
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	InactiveURL *string `gorm:"type:text"`
+
+	// Reglas de destino por país, en orden de evaluación
+	GeoRules    []GeoRuleModel    `gorm:"type:jsonb;serializer:json"`
+	DeviceRules []DeviceRuleModel `gorm:"type:jsonb;serializer:json"`
+	Variants    []VariantModel    `gorm:"type:jsonb;serializer:json"`
+
+	// Dominio personalizado (nil = dominio compartido)
+	Domain *string `gorm:"size:253;index"`
+
+	Title  *string  `gorm:"type:text"`
+	Tags   []string `gorm:"type:jsonb;serializer:json"`
+	Folder *string  `gorm:"size:64;index"`
+
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+	ArchivedAt *time.Time
+	// Borrado lógico: GORM excluye de las consultas las filas en la papelera
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	// Solo lectura: se llena con el subquery de clicks en los listados
+	TotalClicks int64 `gorm:"->;-:migration"`
 
 	// Relación (GORM usará UserID como Foreign key)
 	User authGormModels.UserModel `gorm:"foreignKey:UserID"`
@@ -26,4 +73,107 @@ type ShortLinkModel struct {
 
 func (ShortLinkModel) TableName() string {
 	return "short_links"
-}
\ No newline at end of file
+}
+
+// UTMModel guarda los parámetros UTM en columnas utm_* de short_links
+// (y como JSON dentro de las revisiones)
+type UTMModel struct {
+	Source   string `gorm:"size:255" json:"source,omitempty"`
+	Medium   string `gorm:"size:255" json:"medium,omitempty"`
+	Campaign string `gorm:"size:255" json:"campaign,omitempty"`
+	Term     string `gorm:"size:255" json:"term,omitempty"`
+	Content  string `gorm:"size:255" json:"content,omitempty"`
+}
+
+// GeoRuleModel es la forma persistida (JSON) de una regla por país
+type GeoRuleModel struct {
+	Countries []string `json:"countries"`
+	URL       string   `json:"url"`
+}
+
+// DeviceRuleModel es la forma persistida (JSON) de una regla por plataforma
+type DeviceRuleModel struct {
+	Platforms []string `json:"platforms"`
+	URL       string   `json:"url"`
+}
+
+// VariantModel es la forma persistida (JSON) de una variante A/B
+type VariantModel struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Weight int    `json:"weight"`
+}
+
+// RevisionModel representa la tabla link_revisions
+type RevisionModel struct {
+	ID             uint              `gorm:"primaryKey;autoIncrement"`
+	LinkCode       string            `gorm:"not null;index"`
+	Action         string            `gorm:"size:16;not null"`
+	OldURL         *string           `gorm:"type:text"`
+	NewURL         string            `gorm:"type:text;not null"`
+	Settings       LinkSettingsModel `gorm:"type:jsonb;serializer:json"`
+	ActorType      string            `gorm:"size:16"`
+	ActorUserID    *string           `gorm:"type:text"`
+	RestoredFromID *uint
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+func (RevisionModel) TableName() string {
+	return "link_revisions"
+}
+
+// LinkSettingsModel es la forma persistida (JSON) de la configuración de un
+// enlace dentro de una revisión
+type LinkSettingsModel struct {
+	OriginalURL   string            `json:"originalUrl"`
+	ExpiresAt     *time.Time        `json:"expiresAt,omitempty"`
+	PasswordHash  string            `json:"passwordHash,omitempty"`
+	MaxClicks     *int64            `json:"maxClicks,omitempty"`
+	RedirectType  int               `json:"redirectType"`
+	ForwardQuery  bool              `json:"forwardQuery"`
+	QueryPriority string            `json:"queryPriority"`
+	ForwardPath   bool              `json:"forwardPath"`
+	UTM           UTMModel          `json:"utm"`
+	StartsAt      *time.Time        `json:"startsAt,omitempty"`
+	EndsAt        *time.Time        `json:"endsAt,omitempty"`
+	InactiveURL   string            `json:"inactiveUrl,omitempty"`
+	GeoRules      []GeoRuleModel    `json:"geoRules,omitempty"`
+	DeviceRules   []DeviceRuleModel `json:"deviceRules,omitempty"`
+	Variants      []VariantModel    `json:"variants,omitempty"`
+	Title         string            `json:"title,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	Folder        string            `json:"folder,omitempty"`
+	Domain        string            `json:"domain,omitempty"`
+}
+
+// ReportModel representa la tabla link_reports
+type ReportModel struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`
+	LinkCode       string    `gorm:"not null;index"`
+	Reason         string    `gorm:"size:32;not null"`
+	Details        *string   `gorm:"type:text"`
+	ReporterIP     string    `gorm:"size:45"`
+	ReporterUserID *string   `gorm:"type:text"`
+	Status         string    `gorm:"size:16;not null;default:'open';index"`
+	CreatedAt      time.Time `gorm:"autoCreateTime;index"`
+	ResolvedAt     *time.Time
+	ResolvedBy     *string `gorm:"type:text"`
+}
+
+func (ReportModel) TableName() string {
+	return "link_reports"
+}
+
+// ArchivedShortLinkModel guarda una copia de los enlaces expirados que el
+// mantenimiento retiró de short_links. Data contiene la fila original en JSON
+type ArchivedShortLinkModel struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	Code       string    `gorm:"size:32;not null;index"`
+	UserID     *string   `gorm:"index"`
+	Data       string    `gorm:"type:jsonb;not null"`
+	ArchivedAt time.Time `gorm:"not null"`
+}
+
+func (ArchivedShortLinkModel) TableName() string {
+	return "short_links_archive"
+}
@@ -0,0 +1,97 @@
+package gorm
+
+import (
+	derefUtils "short-go/internal/shared/http/utils"
+	"short-go/internal/short-links/domain/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReportRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) *ReportRepositoryGorm {
+	return &ReportRepositoryGorm{db: db}
+}
+
+func (r *ReportRepositoryGorm) Create(report *model.Report) error {
+	reportModel := &ReportModel{
+		LinkCode:       report.LinkCode,
+		Reason:         report.Reason,
+		Details:        nullableString(report.Details),
+		ReporterIP:     report.ReporterIP,
+		ReporterUserID: report.ReporterUserID,
+		Status:         report.Status,
+		CreatedAt:      report.CreatedAt,
+	}
+
+	if err := r.db.Create(reportModel).Error; err != nil {
+		return err
+	}
+
+	report.ID = reportModel.ID
+	return nil
+}
+
+func (r *ReportRepositoryGorm) HasOpenReport(code string, reporterIP string) (bool, error) {
+	var count int64
+	err := r.db.Model(&ReportModel{}).
+		Where("link_code = ? AND reporter_ip = ? AND status = ?", code, reporterIP, model.ReportStatusOpen).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+func (r *ReportRepositoryGorm) List(opts model.ReportListOptions) ([]*model.Report, int64, error) {
+	query := r.db.Model(&ReportModel{})
+	if opts.Status != "" {
+		query = query.Where("status = ?", opts.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reportModels []ReportModel
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Offset((opts.Page - 1) * opts.PageSize).
+		Limit(opts.PageSize).
+		Find(&reportModels).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reports := make([]*model.Report, len(reportModels))
+	for i, m := range reportModels {
+		reports[i] = &model.Report{
+			ID:             m.ID,
+			LinkCode:       m.LinkCode,
+			Reason:         m.Reason,
+			Details:        derefUtils.DerefString(m.Details),
+			ReporterIP:     m.ReporterIP,
+			ReporterUserID: m.ReporterUserID,
+			Status:         m.Status,
+			CreatedAt:      m.CreatedAt,
+			ResolvedAt:     m.ResolvedAt,
+			ResolvedBy:     m.ResolvedBy,
+		}
+	}
+
+	return reports, total, nil
+}
+
+func (r *ReportRepositoryGorm) CloseOpenReports(code string, status string, resolvedBy string, at time.Time) (int64, error) {
+	result := r.db.Model(&ReportModel{}).
+		Where("link_code = ? AND status = ?", code, model.ReportStatusOpen).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"resolved_by": resolvedBy,
+			"resolved_at": at,
+		})
+	return result.RowsAffected, result.Error
+}
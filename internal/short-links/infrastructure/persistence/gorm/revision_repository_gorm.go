@@ -0,0 +1,130 @@
+package gorm
+
+import (
+	derefUtils "short-go/internal/shared/http/utils"
+	"short-go/internal/short-links/domain/model"
+)
+
+func (r *ShortLinkRepositoryGorm) CreateRevision(revision *model.Revision) error {
+	revisionModel := &RevisionModel{
+		LinkCode:       revision.LinkCode,
+		Action:         revision.Action,
+		OldURL:         nullableString(revision.OldURL),
+		NewURL:         revision.NewURL,
+		Settings:       toSettingsModel(revision.Settings),
+		ActorType:      revision.ActorType,
+		ActorUserID:    revision.ActorUserID,
+		RestoredFromID: revision.RestoredFromID,
+		CreatedAt:      revision.CreatedAt,
+	}
+
+	if err := r.db.Create(revisionModel).Error; err != nil {
+		return err
+	}
+
+	revision.ID = revisionModel.ID
+	return nil
+}
+
+func (r *ShortLinkRepositoryGorm) FindRevisions(code string, page, pageSize int) ([]*model.Revision, int64, error) {
+	var total int64
+	if err := r.db.Model(&RevisionModel{}).Where("link_code = ?", code).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var revisionModels []RevisionModel
+	err := r.db.Where("link_code = ?", code).
+		Order("id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&revisionModels).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	revisions := make([]*model.Revision, len(revisionModels))
+	for i := range revisionModels {
+		revisions[i] = toRevision(&revisionModels[i])
+	}
+
+	return revisions, total, nil
+}
+
+func (r *ShortLinkRepositoryGorm) FindRevision(code string, id uint) (*model.Revision, error) {
+	var revisionModel RevisionModel
+	if err := r.db.Where("link_code = ? AND id = ?", code, id).First(&revisionModel).Error; err != nil {
+		return nil, err
+	}
+
+	return toRevision(&revisionModel), nil
+}
+
+func (r *ShortLinkRepositoryGorm) HasRevisions(code string) (bool, error) {
+	var count int64
+	err := r.db.Model(&RevisionModel{}).Where("link_code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+// ------------------------------ HELPERS -----------------------------------
+func toRevision(revisionModel *RevisionModel) *model.Revision {
+	return &model.Revision{
+		ID:             revisionModel.ID,
+		LinkCode:       revisionModel.LinkCode,
+		Action:         revisionModel.Action,
+		OldURL:         derefUtils.DerefString(revisionModel.OldURL),
+		NewURL:         revisionModel.NewURL,
+		Settings:       toSettings(revisionModel.Settings),
+		ActorType:      revisionModel.ActorType,
+		ActorUserID:    revisionModel.ActorUserID,
+		RestoredFromID: revisionModel.RestoredFromID,
+		CreatedAt:      revisionModel.CreatedAt,
+	}
+}
+
+func toSettingsModel(settings model.LinkSettings) LinkSettingsModel {
+	return LinkSettingsModel{
+		OriginalURL:   settings.OriginalURL,
+		ExpiresAt:     settings.ExpiresAt,
+		PasswordHash:  settings.PasswordHash,
+		MaxClicks:     settings.MaxClicks,
+		RedirectType:  settings.RedirectType,
+		ForwardQuery:  settings.ForwardQuery,
+		QueryPriority: settings.QueryPriority,
+		ForwardPath:   settings.ForwardPath,
+		UTM:           UTMModel(settings.UTM),
+		StartsAt:      settings.StartsAt,
+		EndsAt:        settings.EndsAt,
+		InactiveURL:   settings.InactiveURL,
+		GeoRules:      toGeoRuleModels(settings.GeoRules),
+		DeviceRules:   toDeviceRuleModels(settings.DeviceRules),
+		Variants:      toVariantModels(settings.Variants),
+		Title:         settings.Title,
+		Tags:          settings.Tags,
+		Folder:        settings.Folder,
+		Domain:        settings.Domain,
+	}
+}
+
+func toSettings(settingsModel LinkSettingsModel) model.LinkSettings {
+	return model.LinkSettings{
+		OriginalURL:   settingsModel.OriginalURL,
+		ExpiresAt:     settingsModel.ExpiresAt,
+		PasswordHash:  settingsModel.PasswordHash,
+		MaxClicks:     settingsModel.MaxClicks,
+		RedirectType:  settingsModel.RedirectType,
+		ForwardQuery:  settingsModel.ForwardQuery,
+		QueryPriority: settingsModel.QueryPriority,
+		ForwardPath:   settingsModel.ForwardPath,
+		UTM:           model.UTM(settingsModel.UTM),
+		StartsAt:      settingsModel.StartsAt,
+		EndsAt:        settingsModel.EndsAt,
+		InactiveURL:   settingsModel.InactiveURL,
+		GeoRules:      toGeoRules(settingsModel.GeoRules),
+		DeviceRules:   toDeviceRules(settingsModel.DeviceRules),
+		Variants:      toVariants(settingsModel.Variants),
+		Title:         settingsModel.Title,
+		Tags:          settingsModel.Tags,
+		Folder:        settingsModel.Folder,
+		Domain:        settingsModel.Domain,
+	}
+}
@@ -1,13 +1,59 @@
 package gorm
 
 import (
+	"encoding/json"
 	"errors"
 	derefUtils "short-go/internal/shared/http/utils"
 	"short-go/internal/short-links/domain/model"
+	"short-go/internal/short-links/domain/repository"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// editableColumns son las columnas que Update sobrescribe
+var editableColumns = []string{
+	"original_url",
+	"expires_at",
+	"password_hash",
+	"max_clicks",
+	"redirect_type",
+	"forward_query",
+	"query_priority",
+	"forward_path",
+	"utm_source",
+	"utm_medium",
+	"utm_campaign",
+	"utm_term",
+	"utm_content",
+	"quarantined",
+	"quarantine_reason",
+	"starts_at",
+	"ends_at",
+	"inactive_url",
+	"geo_rules",
+	"device_rules",
+	"variants",
+	"title",
+	"tags",
+	"folder",
+	"domain",
+	"updated_at",
+}
+
+// searchDocument es el texto indexado para la búsqueda: código, título y
+// destino con la puntuación reemplazada por espacios, para que "ejemplo"
+// encuentre https://ejemplo.com/ruta. Debe coincidir con el índice de
+// MigrateSearchIndex para que Postgres lo use
+const searchDocument = `to_tsvector('simple', code || ' ' || coalesce(title, '') || ' ' || regexp_replace(original_url, '[^[:alnum:]]+', ' ', 'g'))`
+
+// destinationHost extrae el host del destino guardado
+const destinationHost = `lower(substring(original_url from '^[a-zA-Z][a-zA-Z0-9+.-]*://([^/:?#]+)'))`
+
+// totalClicksSelect agrega el conteo de clicks de cada enlace a la consulta
+const totalClicksSelect = "short_links.*, (SELECT COUNT(*) FROM clicks WHERE clicks.link_code = short_links.code) AS total_clicks"
+
 type ShortLinkRepositoryGorm struct {
 	db *gorm.DB
 }
@@ -17,70 +63,573 @@ func NewShortLinkRepository(db *gorm.DB) *ShortLinkRepositoryGorm {
 }
 
 func (r *ShortLinkRepositoryGorm) Create(shortLink *model.ShortLink) error {
-	shortLinkModel := &ShortLinkModel{
-		Code: shortLink.Code,
-		OriginalURL: shortLink.OriginalURL,
-		ManagementToken: &shortLink.ManagementToken,
-		ExpiresAt: &shortLink.ExpiresAt,
-		CreatedAt: shortLink.CreatedAt,
-		UpdatedAt: shortLink.UpdatedAt,
-		UserID: shortLink.UserID,
+	// El índice único no cubre short_links_archive: un código archivado
+	// conserva sus clicks, revisiones y reportes y no se puede reutilizar
+	archived, err := r.codeArchived(shortLink.Code)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return repository.ErrDuplicateCode
 	}
 
-	if err := r.db.Create(shortLinkModel).Error; err != nil {
-		return err
+	shortLinkModel := toModel(shortLink)
+
+	// ON CONFLICT DO NOTHING evita abortar transacciones por colisiones;
+	// si no se insertó ninguna fila, el código o el token ya existían
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(shortLinkModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrDuplicateCode
 	}
 
 	return nil
 }
 
+// Transaction ejecuta fn con un repositorio ligado a una transacción; si fn
+// retorna error se revierte todo lo hecho dentro
+func (r *ShortLinkRepositoryGorm) Transaction(fn func(repo repository.ShortLinkRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&ShortLinkRepositoryGorm{db: tx})
+	})
+}
+
 func (r *ShortLinkRepositoryGorm) FindByCode(code string) (*model.ShortLink, error) {
 	var shortLinkModel ShortLinkModel
 
-    result := r.db.Where("code = ?", code).First(&shortLinkModel)
-    
-    if result.Error != nil {
-        if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-            return nil, result.Error
-        }
-        return nil, result.Error
-    }
-	
-	shortLink := &model.ShortLink{
-		Code: shortLinkModel.Code,
-		OriginalURL: shortLinkModel.OriginalURL,
-		UserID: shortLinkModel.UserID,
-		ManagementToken: derefUtils.DerefString(shortLinkModel.ManagementToken),
-		ExpiresAt: derefUtils.DerefTime(shortLinkModel.ExpiresAt),
-		CreatedAt: shortLinkModel.CreatedAt,
-		UpdatedAt: shortLinkModel.UpdatedAt,
+	result := r.db.Select(totalClicksSelect).Where("code = ?", code).First(&shortLinkModel)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, result.Error
+		}
+		return nil, result.Error
 	}
 
-	return shortLink, nil
+	return toDomain(&shortLinkModel), nil
+}
+
+func (r *ShortLinkRepositoryGorm) CodeTaken(code string) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&ShortLinkModel{}).Where("code = ?", code).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	return r.codeArchived(code)
+}
+
+// codeArchived indica si el código pertenece a un enlace archivado por el mantenimiento
+func (r *ShortLinkRepositoryGorm) codeArchived(code string) (bool, error) {
+	var count int64
+	err := r.db.Model(&ArchivedShortLinkModel{}).Where("code = ?", code).Count(&count).Error
+	return count > 0, err
 }
 
 func (r *ShortLinkRepositoryGorm) FindByManagementToken(token string) (*model.ShortLink, error) {
 	var shortLinkModel ShortLinkModel
-	if err := r.db.Where("management_token = ?", token).Find(&shortLinkModel).Error; err != nil {
+	if err := r.db.Where("management_token = ?", token).First(&shortLinkModel).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomain(&shortLinkModel), nil
+}
+
+func (r *ShortLinkRepositoryGorm) FindByCodes(codes []string) ([]*model.ShortLink, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	var shortLinkModels []ShortLinkModel
+	if err := r.db.Where("code IN ?", codes).Find(&shortLinkModels).Error; err != nil {
+		return nil, err
+	}
+
+	shortLinks := make([]*model.ShortLink, len(shortLinkModels))
+	for i := range shortLinkModels {
+		shortLinks[i] = toDomain(&shortLinkModels[i])
+	}
+
+	return shortLinks, nil
+}
+
+func (r *ShortLinkRepositoryGorm) FindByUserID(userID string, opts model.ListOptions) ([]*model.ShortLink, int64, error) {
+	var total int64
+	if err := r.userLinks(userID, opts.Filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	orderColumn := "created_at"
+	if opts.SortBy == model.SortByClicks {
+		orderColumn = "total_clicks"
+	}
+
+	var shortLinkModels []ShortLinkModel
+	err := r.userLinks(userID, opts.Filter).
+		Select(totalClicksSelect).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: orderColumn}, Desc: opts.SortDesc}).
+		Order("code ASC").
+		Offset((opts.Page - 1) * opts.PageSize).
+		Limit(opts.PageSize).
+		Find(&shortLinkModels).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	shortLinks := make([]*model.ShortLink, len(shortLinkModels))
+	for i := range shortLinkModels {
+		shortLinks[i] = toDomain(&shortLinkModels[i])
+	}
+
+	return shortLinks, total, nil
+}
+
+func (r *ShortLinkRepositoryGorm) ListTags(userID string) ([]model.TagCount, error) {
+	var tags []model.TagCount
+	err := r.db.Raw(
+		`SELECT tag, COUNT(*) AS count
+		FROM short_links, jsonb_array_elements_text(short_links.tags) AS tag
+		WHERE user_id = ? AND deleted_at IS NULL AND jsonb_typeof(short_links.tags) = 'array'
+		GROUP BY tag ORDER BY tag`, userID,
+	).Scan(&tags).Error
+
+	return tags, err
+}
+
+func (r *ShortLinkRepositoryGorm) ListFolders(userID string) ([]model.FolderCount, error) {
+	var folders []model.FolderCount
+	err := r.db.Model(&ShortLinkModel{}).
+		Select("folder, COUNT(*) AS count").
+		Where("user_id = ? AND folder IS NOT NULL", userID).
+		Group("folder").
+		Order("folder").
+		Scan(&folders).Error
+
+	return folders, err
+}
+
+// userLinks es la consulta base del listado: los enlaces del usuario que
+// cumplen el filtro
+func (r *ShortLinkRepositoryGorm) userLinks(userID string, filter model.ListFilter) *gorm.DB {
+	query := r.db.Model(&ShortLinkModel{}).Where("user_id = ?", userID)
+
+	if len(filter.Tags) > 0 {
+		tags, _ := json.Marshal(filter.Tags)
+		query = query.Where("tags @> ?::jsonb", string(tags))
+	}
+	if filter.Folder != "" {
+		query = query.Where("folder = ?", filter.Folder)
+	}
+	if filter.DestinationHost != "" {
+		query = query.Where("("+destinationHost+" = ? OR "+destinationHost+" LIKE ?)", filter.DestinationHost, "%."+filter.DestinationHost)
+	}
+	if filter.Domain != "" {
+		query = query.Where("domain = ?", filter.Domain)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.Query != "" {
+		query = query.Where(searchDocument+" @@ to_tsquery('simple', ?)", filter.Query)
+	}
+	if filter.Archived {
+		query = query.Where("archived_at IS NOT NULL")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	return query
+}
+
+func (r *ShortLinkRepositoryGorm) Update(shortLink *model.ShortLink) error {
+	// Select limita la actualización a las columnas editables, incluyendo
+	// valores nulos o vacíos que Updates omitiría con un struct
+	return r.db.Model(&ShortLinkModel{}).
+		Where("code = ?", shortLink.Code).
+		Select(editableColumns).
+		Updates(toModel(shortLink)).Error
+}
+
+func (r *ShortLinkRepositoryGorm) AssignOwner(code string, userID string) (bool, error) {
+	// La condición user_id IS NULL hace el reclamo atómico frente a reclamos
+	// simultáneos. El token se anula en el mismo UPDATE: desde ahora solo el
+	// dueño gestiona el enlace
+	result := r.db.Model(&ShortLinkModel{}).
+		Where("code = ? AND user_id IS NULL", code).
+		Updates(map[string]interface{}{
+			"user_id":          userID,
+			"management_token": nil,
+			"updated_at":       time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *ShortLinkRepositoryGorm) ConsumeClick(code string) (bool, error) {
+	// La condición dentro del UPDATE evita superar el límite con peticiones concurrentes
+	result := r.db.Model(&ShortLinkModel{}).
+		Where("code = ? AND (max_clicks IS NULL OR consumed_clicks < max_clicks)", code).
+		UpdateColumn("consumed_clicks", gorm.Expr("consumed_clicks + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *ShortLinkRepositoryGorm) SetModeration(code string, moderation model.Moderation) (bool, error) {
+	// Columnas fuera de editableColumns: una edición del dueño no pisa la moderación
+	result := r.db.Model(&ShortLinkModel{}).
+		Where("code = ?", code).
+		Updates(map[string]interface{}{
+			"moderation_status": moderation.Status,
+			"moderation_reason": nullableString(moderation.Reason),
+			"moderated_by":      moderation.ModeratedBy,
+			"moderated_at":      moderation.ModeratedAt,
+			"updated_at":        moderation.ModeratedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *ShortLinkRepositoryGorm) DeleteByCode(code string) error {
+	// Con DeletedAt, Delete solo marca la fila: los clicks y el código se conservan
+	if err := r.db.Where("code = ?", code).Delete(&ShortLinkModel{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *ShortLinkRepositoryGorm) FindDeletedByCode(code string) (*model.ShortLink, error) {
+	var shortLinkModel ShortLinkModel
+	err := r.db.Unscoped().
+		Select(totalClicksSelect).
+		Where("code = ? AND deleted_at IS NOT NULL", code).
+		First(&shortLinkModel).Error
+	if err != nil {
 		return nil, err
 	}
 
-	shortLink := &model.ShortLink{
+	return toDomain(&shortLinkModel), nil
+}
+
+func (r *ShortLinkRepositoryGorm) FindDeletedByUserID(userID string, since time.Time, page, pageSize int) ([]*model.ShortLink, int64, error) {
+	trash := func() *gorm.DB {
+		return r.db.Unscoped().Model(&ShortLinkModel{}).Where("user_id = ? AND deleted_at > ?", userID, since)
+	}
+
+	var total int64
+	if err := trash().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var shortLinkModels []ShortLinkModel
+	err := trash().
+		Select(totalClicksSelect).
+		Order("deleted_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&shortLinkModels).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	shortLinks := make([]*model.ShortLink, len(shortLinkModels))
+	for i := range shortLinkModels {
+		shortLinks[i] = toDomain(&shortLinkModels[i])
+	}
+
+	return shortLinks, total, nil
+}
+
+func (r *ShortLinkRepositoryGorm) RestoreDeleted(code string) (bool, error) {
+	result := r.db.Unscoped().Model(&ShortLinkModel{}).
+		Where("code = ? AND deleted_at IS NOT NULL", code).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *ShortLinkRepositoryGorm) SetArchived(code string, at *time.Time) (bool, error) {
+	result := r.db.Model(&ShortLinkModel{}).
+		Where("code = ?", code).
+		Updates(map[string]interface{}{
+			"archived_at": at,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// DetachDomain devuelve al dominio compartido los enlaces del usuario
+// publicados en host, incluidos los de la papelera
+func (r *ShortLinkRepositoryGorm) DetachDomain(userID string, host string) (int64, error) {
+	result := r.db.Unscoped().Model(&ShortLinkModel{}).
+		Where("user_id = ? AND domain = ?", userID, host).
+		Updates(map[string]interface{}{
+			"domain":     nil,
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// notBanned excluye del mantenimiento a los enlaces bloqueados: su código
+// queda reservado para siempre, igual que al impedir que el dueño los elimine
+const notBanned = "moderation_status <> '" + model.ModerationBanned + "'"
+
+// PurgeExpired elimina los enlaces expirados antes de cutoff junto con sus clicks
+func (r *ShortLinkRepositoryGorm) PurgeExpired(cutoff time.Time) (int64, error) {
+	var purged int64
+
+	// Los enlaces en la papelera los retira PurgeDeleted al terminar su cuarentena
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		const expired = "expires_at < ? AND deleted_at IS NULL AND " + notBanned
+		if err := deleteLinkData(tx, expired, cutoff); err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where(expired, cutoff).Delete(&ShortLinkModel{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+
+	return purged, err
+}
+
+// PurgeDeleted elimina definitivamente los enlaces que están en la papelera
+// desde antes de cutoff; a partir de ahí su código vuelve a estar disponible
+func (r *ShortLinkRepositoryGorm) PurgeDeleted(cutoff time.Time) (int64, error) {
+	var purged int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		const deleted = "deleted_at < ? AND " + notBanned
+		if err := deleteLinkData(tx, deleted, cutoff); err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where(deleted, cutoff).Delete(&ShortLinkModel{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+
+	return purged, err
+}
+
+// ArchiveExpired mueve los enlaces expirados antes de cutoff a short_links_archive
+// en una sola sentencia. Los clicks, revisiones y reportes se conservan para el
+// histórico, por eso Create y CodeTaken siguen tratando el código como ocupado
+func (r *ShortLinkRepositoryGorm) ArchiveExpired(cutoff time.Time) (int64, error) {
+	result := r.db.Exec(
+		`WITH moved AS (DELETE FROM short_links WHERE expires_at < ? AND deleted_at IS NULL AND `+notBanned+` RETURNING *)
+		INSERT INTO short_links_archive (code, user_id, data, archived_at)
+		SELECT code, user_id, to_jsonb(moved), NOW() FROM moved`, cutoff,
+	)
+
+	return result.RowsAffected, result.Error
+}
+
+// ------------------------------ HELPERS -----------------------------------
+// deleteLinkData elimina los clicks, revisiones y reportes de los enlaces que
+// cumplen condition, antes de eliminar los enlaces en la misma transacción
+func deleteLinkData(tx *gorm.DB, condition string, cutoff time.Time) error {
+	for _, table := range []string{"clicks", "link_revisions", "link_reports"} {
+		if err := tx.Exec(
+			"DELETE FROM "+table+" WHERE link_code IN (SELECT code FROM short_links WHERE "+condition+")", cutoff,
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toModel convierte model.ShortLink -> ShortLinkModel
+func toModel(shortLink *model.ShortLink) *ShortLinkModel {
+	return &ShortLinkModel{
+		Code: shortLink.Code,
+		OriginalURL: shortLink.OriginalURL,
+		ManagementToken: nullableString(shortLink.ManagementToken),
+		ExpiresAt: shortLink.ExpiresAt,
+		CreatedAt: shortLink.CreatedAt,
+		UpdatedAt: shortLink.UpdatedAt,
+		UserID: shortLink.UserID,
+		PasswordHash: nullableString(shortLink.PasswordHash),
+		MaxClicks: shortLink.MaxClicks,
+		RedirectType: shortLink.RedirectType,
+		ForwardQuery: shortLink.ForwardQuery,
+		QueryPriority: shortLink.QueryPriority,
+		ForwardPath: shortLink.ForwardPath,
+		UTM: UTMModel(shortLink.UTM),
+		Quarantined: shortLink.Quarantined,
+		QuarantineReason: nullableString(shortLink.QuarantineReason),
+		ModerationStatus: shortLink.ModerationStatus,
+		ModerationReason: nullableString(shortLink.ModerationReason),
+		ModeratedBy: shortLink.ModeratedBy,
+		ModeratedAt: shortLink.ModeratedAt,
+		StartsAt: shortLink.StartsAt,
+		EndsAt: shortLink.EndsAt,
+		InactiveURL: nullableString(shortLink.InactiveURL),
+		GeoRules:    toGeoRuleModels(shortLink.GeoRules),
+		DeviceRules: toDeviceRuleModels(shortLink.DeviceRules),
+		Variants:    toVariantModels(shortLink.Variants),
+		Title:       nullableString(shortLink.Title),
+		Tags:        shortLink.Tags,
+		Folder:      nullableString(shortLink.Folder),
+		Domain:      nullableString(shortLink.Domain),
+		ArchivedAt:  shortLink.ArchivedAt,
+	}
+}
+
+// toDomain convierte ShortLinkModel -> model.ShortLink
+func toDomain(shortLinkModel *ShortLinkModel) *model.ShortLink {
+	return &model.ShortLink{
 		Code: shortLinkModel.Code,
 		OriginalURL: shortLinkModel.OriginalURL,
 		UserID: shortLinkModel.UserID,
 		ManagementToken: derefUtils.DerefString(shortLinkModel.ManagementToken),
-		ExpiresAt: derefUtils.DerefTime(shortLinkModel.ExpiresAt),
+		ExpiresAt: shortLinkModel.ExpiresAt,
 		CreatedAt: shortLinkModel.CreatedAt,
 		UpdatedAt: shortLinkModel.UpdatedAt,
+		TotalClicks: shortLinkModel.TotalClicks,
+		PasswordHash: derefUtils.DerefString(shortLinkModel.PasswordHash),
+		MaxClicks: shortLinkModel.MaxClicks,
+		RedirectType: shortLinkModel.RedirectType,
+		ForwardQuery: shortLinkModel.ForwardQuery,
+		QueryPriority: shortLinkModel.QueryPriority,
+		ForwardPath: shortLinkModel.ForwardPath,
+		UTM: model.UTM(shortLinkModel.UTM),
+		Quarantined: shortLinkModel.Quarantined,
+		QuarantineReason: derefUtils.DerefString(shortLinkModel.QuarantineReason),
+		ModerationStatus: shortLinkModel.ModerationStatus,
+		ModerationReason: derefUtils.DerefString(shortLinkModel.ModerationReason),
+		ModeratedBy: shortLinkModel.ModeratedBy,
+		ModeratedAt: shortLinkModel.ModeratedAt,
+		ConsumedClicks: shortLinkModel.ConsumedClicks,
+		StartsAt: shortLinkModel.StartsAt,
+		EndsAt: shortLinkModel.EndsAt,
+		InactiveURL: derefUtils.DerefString(shortLinkModel.InactiveURL),
+		GeoRules:    toGeoRules(shortLinkModel.GeoRules),
+		DeviceRules: toDeviceRules(shortLinkModel.DeviceRules),
+		Variants:    toVariants(shortLinkModel.Variants),
+		Title:       derefUtils.DerefString(shortLinkModel.Title),
+		Tags:        shortLinkModel.Tags,
+		Folder:      derefUtils.DerefString(shortLinkModel.Folder),
+		Domain:      derefUtils.DerefString(shortLinkModel.Domain),
+		ArchivedAt:  shortLinkModel.ArchivedAt,
+		DeletedAt:   deletedAt(shortLinkModel.DeletedAt),
+	}
+}
+
+func toGeoRuleModels(rules []model.GeoRule) []GeoRuleModel {
+	if len(rules) == 0 {
+		return nil
+	}
+	models := make([]GeoRuleModel, len(rules))
+	for i, rule := range rules {
+		models[i] = GeoRuleModel{Countries: rule.Countries, URL: rule.URL}
 	}
+	return models
+}
 
-	return shortLink, nil
+func toGeoRules(models []GeoRuleModel) []model.GeoRule {
+	if len(models) == 0 {
+		return nil
+	}
+	rules := make([]model.GeoRule, len(models))
+	for i, m := range models {
+		rules[i] = model.GeoRule{Countries: m.Countries, URL: m.URL}
+	}
+	return rules
 }
 
-func (r *ShortLinkRepositoryGorm) DeleteByCode(code string) error {
-	if err := r.db.Where("code = ?", code).Delete(&ShortLinkModel{}).Error; err != nil {
-		return err
+// deletedAt convierte el borrado lógico de GORM a *time.Time
+func deletedAt(value gorm.DeletedAt) *time.Time {
+	if !value.Valid {
+		return nil
 	}
-	return nil
-}
\ No newline at end of file
+	return &value.Time
+}
+
+// nullableString guarda los strings vacíos como NULL
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func toDeviceRuleModels(rules []model.DeviceRule) []DeviceRuleModel {
+	if len(rules) == 0 {
+		return nil
+	}
+	models := make([]DeviceRuleModel, len(rules))
+	for i, rule := range rules {
+		models[i] = DeviceRuleModel{Platforms: rule.Platforms, URL: rule.URL}
+	}
+	return models
+}
+
+func toDeviceRules(models []DeviceRuleModel) []model.DeviceRule {
+	if len(models) == 0 {
+		return nil
+	}
+	rules := make([]model.DeviceRule, len(models))
+	for i, m := range models {
+		rules[i] = model.DeviceRule{Platforms: m.Platforms, URL: m.URL}
+	}
+	return rules
+}
+
+func toVariantModels(variants []model.Variant) []VariantModel {
+	if len(variants) == 0 {
+		return nil
+	}
+	models := make([]VariantModel, len(variants))
+	for i, v := range variants {
+		models[i] = VariantModel{Name: v.Name, URL: v.URL, Weight: v.Weight}
+	}
+	return models
+}
+
+func toVariants(models []VariantModel) []model.Variant {
+	if len(models) == 0 {
+		return nil
+	}
+	variants := make([]model.Variant, len(models))
+	for i, m := range models {
+		variants[i] = model.Variant{Name: m.Name, URL: m.URL, Weight: m.Weight}
+	}
+	return variants
+}
+
+// MigrateSearchIndex crea el índice GIN de la búsqueda de texto completo, que
+// AutoMigrate no puede declarar por ser un índice sobre una expresión
+func MigrateSearchIndex(db *gorm.DB) error {
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_short_links_search ON short_links USING GIN (" + searchDocument + ")").Error
+}
+
+// RevokeOwnedManagementTokens anula los tokens de gestión que aún conservan
+// enlaces con dueño, reclamados antes de que el reclamo los revocara
+func RevokeOwnedManagementTokens(db *gorm.DB) error {
+	return db.Model(&ShortLinkModel{}).
+		Where("user_id IS NOT NULL AND management_token IS NOT NULL").
+		Update("management_token", nil).Error
+}
@@ -0,0 +1,101 @@
+package safety
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"short-go/internal/shared/urlutil"
+	"short-go/internal/short-links/application/service"
+	"strings"
+)
+
+// BlocklistChecker marca las URLs cuyo dominio (o un dominio padre) o cuyo
+// prefijo de URL aparece en listas locales
+type BlocklistChecker struct {
+	domains     map[string]bool
+	urlPrefixes []string
+	verdict     service.SafetyVerdict
+}
+
+// NewBlocklistChecker carga las listas indicadas. Cada línea es un dominio
+// (bloquea también sus subdominios) o una URL completa (bloquea ese prefijo);
+// las líneas vacías y las que empiezan con # se ignoran. Las coincidencias
+// reciben el veredicto indicado
+func NewBlocklistChecker(paths []string, verdict service.SafetyVerdict) (*BlocklistChecker, error) {
+	checker := &BlocklistChecker{
+		domains: map[string]bool{},
+		verdict: verdict,
+	}
+
+	for _, path := range paths {
+		if err := checker.load(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return checker, nil
+}
+
+func (c *BlocklistChecker) load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		if strings.Contains(entry, "://") {
+			c.urlPrefixes = append(c.urlPrefixes, strings.ToLower(entry))
+			continue
+		}
+
+		domain, err := urlutil.HostToASCII(strings.TrimPrefix(entry, "*."))
+		if err != nil {
+			return fmt.Errorf("%s:%d: dominio inválido %q", path, lineNumber, entry)
+		}
+		c.domains[domain] = true
+	}
+
+	return scanner.Err()
+}
+
+// Size devuelve la cantidad de entradas cargadas
+func (c *BlocklistChecker) Size() int {
+	return len(c.domains) + len(c.urlPrefixes)
+}
+
+func (c *BlocklistChecker) Check(rawURL string) (service.SafetyResult, error) {
+	lowered := strings.ToLower(rawURL)
+	for _, prefix := range c.urlPrefixes {
+		if strings.HasPrefix(lowered, prefix) {
+			return service.SafetyResult{Verdict: c.verdict, Reason: "URL en lista de bloqueo"}, nil
+		}
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return service.SafetyResult{Verdict: service.SafetyClean}, nil
+	}
+
+	// Revisa el dominio y cada dominio padre: a.b.evil.com -> b.evil.com -> evil.com
+	host := strings.ToLower(parsed.Hostname())
+	for host != "" {
+		if c.domains[host] {
+			return service.SafetyResult{Verdict: c.verdict, Reason: "dominio en lista de bloqueo: " + host}, nil
+		}
+		dot := strings.IndexByte(host, '.')
+		if dot < 0 {
+			break
+		}
+		host = host[dot+1:]
+	}
+
+	return service.SafetyResult{Verdict: service.SafetyClean}, nil
+}
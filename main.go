@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"short-go/config"
+	sharedhttp "short-go/internal/shared/http"
 	"short-go/internal/shared/infrastructure"
 	"syscall"
 	"time"
@@ -31,13 +32,21 @@ func main() {
 	// Dependency Injection Container
 	container := infrastructure.NewContainer(db, cfg)
 
+	trustedProxies, err := sharedhttp.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatal("Error cargando TRUSTED_PROXIES:", err)
+	}
+
 	r := chi.NewRouter()
 
+	// IP real del visitante, solo si la petición viene de un proxy confiable
+	r.Use(trustedProxies.Middleware)
+
 	// Configuración de CORS
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins: []string{"http://localhost:5173"}, 
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Management-Token"},
 		AllowCredentials: true,
 		MaxAge:           300, // Cachear la respuesta (preflight) por 5 min
 	}))
@@ -56,7 +65,11 @@ func main() {
 		Handler: r,
 	}
 
-	go gracefulShutdown(server)
+	// Tareas programadas (mantenimiento)
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	container.StartBackgroundJobs(jobsCtx)
+
+	go gracefulShutdown(server, stopJobs)
 
 	log.Printf("Servidor escuchando en %s\n", cfg.Domain+addr)
 	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -64,12 +77,13 @@ func main() {
 	}
 }
 
-func gracefulShutdown(server *http.Server) {
+func gracefulShutdown(server *http.Server, stopJobs context.CancelFunc) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
 	<-quit
 	log.Println("Apagando servidor...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()